@@ -0,0 +1,141 @@
+package hyperliquid
+
+import (
+	"fmt"
+)
+
+// Book is an L2 order book with bids sorted from the highest price
+// and asks sorted from the lowest price.
+type Book struct {
+	Coin string
+	Time int64
+	Bids []BookLevel
+	Asks []BookLevel
+}
+
+// BookDepth is the resting size and notional on each side of the book.
+type BookDepth struct {
+	BidSz  float64
+	AskSz  float64
+	BidNtl float64
+	AskNtl float64
+}
+
+// side returns the levels consumed by an order: asks for a buy, bids for a sell.
+func (b *Book) side(isBuy bool) []BookLevel {
+	if isBuy {
+		return b.Asks
+	}
+	return b.Bids
+}
+
+// BestBid returns the highest bid level.
+// The second value is false if there are no bids.
+func (b *Book) BestBid() (BookLevel, bool) {
+	if len(b.Bids) == 0 {
+		return BookLevel{}, false
+	}
+	return b.Bids[0], true
+}
+
+// BestAsk returns the lowest ask level.
+// The second value is false if there are no asks.
+func (b *Book) BestAsk() (BookLevel, bool) {
+	if len(b.Asks) == 0 {
+		return BookLevel{}, false
+	}
+	return b.Asks[0], true
+}
+
+// Mid returns the middle of the best bid and the best ask.
+// Returns 0 if one side of the book is empty.
+func (b *Book) Mid() float64 {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return 0
+	}
+	return (bid.Px + ask.Px) / 2
+}
+
+// SpreadBps returns the spread between the best bid and the best ask in basis points of the mid price.
+// Returns 0 if one side of the book is empty.
+func (b *Book) SpreadBps() float64 {
+	mid := b.Mid()
+	if mid == 0 {
+		return 0
+	}
+	return (b.Asks[0].Px - b.Bids[0].Px) / mid * 10000
+}
+
+// DepthWithinBps returns the size and notional resting within bps basis points of the mid price.
+// Returns an empty depth if one side of the book is empty.
+func (b *Book) DepthWithinBps(bps float64) BookDepth {
+	var depth BookDepth
+	mid := b.Mid()
+	if mid == 0 {
+		return depth
+	}
+	minPx := mid * (1 - bps/10000)
+	maxPx := mid * (1 + bps/10000)
+	for _, level := range b.Bids {
+		if level.Px < minPx {
+			break
+		}
+		depth.BidSz += level.Sz
+		depth.BidNtl += level.Sz * level.Px
+	}
+	for _, level := range b.Asks {
+		if level.Px > maxPx {
+			break
+		}
+		depth.AskSz += level.Sz
+		depth.AskNtl += level.Sz * level.Px
+	}
+	return depth
+}
+
+// AverageFillPrice returns the average price of an order of the given size sweeping the book
+// and the price of the last level it reaches.
+// A buy sweeps the asks and a sell sweeps the bids.
+// Returns an error if the book does not hold enough liquidity to fill the size.
+func (b *Book) AverageFillPrice(isBuy bool, size float64) (avgPx float64, worstPx float64, err error) {
+	if size <= 0 {
+		return 0, 0, APIError{Message: fmt.Sprintf("Invalid size %v", size)}
+	}
+	remaining := size
+	notional := 0.0
+	for _, level := range b.side(isBuy) {
+		fill := min(remaining, level.Sz)
+		notional += fill * level.Px
+		remaining -= fill
+		worstPx = level.Px
+		if remaining <= 0 {
+			return notional / size, worstPx, nil
+		}
+	}
+	return 0, 0, APIError{Message: fmt.Sprintf("Not enough liquidity in %s book to fill %v, missing %v", b.Coin, size, remaining)}
+}
+
+// SlippageBps returns the cost in basis points of the mid price of sweeping the book with an order of the given size.
+// The result is positive when the average fill price is worse than the mid price.
+func (b *Book) SlippageBps(isBuy bool, size float64) (float64, error) {
+	mid := b.Mid()
+	if mid == 0 {
+		return 0, APIError{Message: fmt.Sprintf("Cannot compute mid price of %s book", b.Coin)}
+	}
+	avgPx, _, err := b.AverageFillPrice(isBuy, size)
+	if err != nil {
+		return 0, err
+	}
+	return slippageBps(isBuy, mid, avgPx), nil
+}
+
+// slippageBps returns the difference between px and the reference price refPx in basis points.
+// The result is positive when px is worse than refPx for the side of the order.
+func slippageBps(isBuy bool, refPx float64, px float64) float64 {
+	if isBuy {
+		return (px - refPx) / refPx * 10000
+	}
+	return (refPx - px) / refPx * 10000
+}
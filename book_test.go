@@ -0,0 +1,88 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func testBook(t *testing.T) *Book {
+	data := `{"coin": "ETH", "time": 1700000000000, "levels": [
+		[{"px": "99", "sz": "1", "n": 1}, {"px": "98", "sz": "2", "n": 2}, {"px": "95", "sz": "5", "n": 1}],
+		[{"px": "101", "sz": "1", "n": 1}, {"px": "102", "sz": "3", "n": 4}, {"px": "110", "sz": "10", "n": 1}]
+	]}`
+	var snapshot L2BookSnapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	return snapshot.Book()
+}
+
+func TestBook_BestPrices(t *testing.T) {
+	book := testBook(t)
+	bid, ok := book.BestBid()
+	if !ok || bid.Px != 99 || bid.Sz != 1 {
+		t.Errorf("BestBid() = %+v, %v", bid, ok)
+	}
+	ask, ok := book.BestAsk()
+	if !ok || ask.Px != 101 || ask.N != 1 {
+		t.Errorf("BestAsk() = %+v, %v", ask, ok)
+	}
+	if mid := book.Mid(); mid != 100 {
+		t.Errorf("Mid() = %v, want %v", mid, 100)
+	}
+	if spread := book.SpreadBps(); !almostEqual(spread, 200) {
+		t.Errorf("SpreadBps() = %v, want %v", spread, 200)
+	}
+	empty := &Book{Coin: "ETH", Bids: book.Bids}
+	if empty.Mid() != 0 || empty.SpreadBps() != 0 {
+		t.Errorf("Mid() = %v, SpreadBps() = %v, want 0 for a one sided book", empty.Mid(), empty.SpreadBps())
+	}
+}
+
+func TestBook_DepthWithinBps(t *testing.T) {
+	book := testBook(t)
+	depth := book.DepthWithinBps(200)
+	if depth.BidSz != 3 || depth.AskSz != 4 {
+		t.Errorf("DepthWithinBps() = %+v, want 3 bid and 4 ask size", depth)
+	}
+	if depth.BidNtl != 99+2*98 || depth.AskNtl != 101+3*102 {
+		t.Errorf("DepthWithinBps() = %+v", depth)
+	}
+}
+
+func TestBook_AverageFillPrice(t *testing.T) {
+	book := testBook(t)
+	testCases := []struct {
+		name     string
+		isBuy    bool
+		size     float64
+		avgPx    float64
+		worstPx  float64
+		slippage float64
+	}{
+		{name: "Buy top level", isBuy: true, size: 1, avgPx: 101, worstPx: 101, slippage: 100},
+		{name: "Buy two levels", isBuy: true, size: 2, avgPx: 101.5, worstPx: 102, slippage: 150},
+		{name: "Sell three levels", isBuy: false, size: 4, avgPx: (99 + 2*98 + 95) / 4.0, worstPx: 95, slippage: 250},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			avgPx, worstPx, err := book.AverageFillPrice(tc.isBuy, tc.size)
+			if err != nil {
+				t.Fatalf("AverageFillPrice() error = %v", err)
+			}
+			if !almostEqual(avgPx, tc.avgPx) || worstPx != tc.worstPx {
+				t.Errorf("AverageFillPrice() = %v, %v, want %v, %v", avgPx, worstPx, tc.avgPx, tc.worstPx)
+			}
+			slippage, err := book.SlippageBps(tc.isBuy, tc.size)
+			if err != nil {
+				t.Fatalf("SlippageBps() error = %v", err)
+			}
+			if !almostEqual(slippage, tc.slippage) {
+				t.Errorf("SlippageBps() = %v, want %v", slippage, tc.slippage)
+			}
+		})
+	}
+	if _, _, err := book.AverageFillPrice(false, 100); err == nil {
+		t.Errorf("AverageFillPrice() error = nil, want not enough liquidity")
+	}
+}
@@ -7,8 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
-
-	log "github.com/sirupsen/logrus"
+	"time"
 )
 
 // IClient is the interface that wraps the basic Request method.
@@ -17,9 +16,11 @@ import (
 // IsMainnet method returns true if the client is connected to the mainnet.
 // debug method enables debug mode.
 // SetPrivateKey method sets the private key for the client.
+// SetKeyManager method sets the private key manager for the client.
 type IClient interface {
 	IAPIService
 	SetPrivateKey(privateKey string) error
+	SetKeyManager(keyManager *PKeyManager)
 	SetAccountAddress(address string)
 	AccountAddress() string
 	SetDebugActive()
@@ -33,13 +34,16 @@ type IClient interface {
 // The debug method prints the debug messages.
 type Client struct {
 	baseUrl        string       // Base URL of the HyperLiquid API
-	privateKey     string       // Private key for the client
 	defaultAddress string       // Default address for the client
 	isMainnet      bool         // Network type
 	Debug          bool         // Debug mode
 	httpClient     *http.Client // HTTP client
 	keyManager     *PKeyManager // Private key manager
-	Logger         *log.Logger  // Logger for debug messages
+	Logger         Logger       // Logger for debug messages
+
+	redactAddresses bool    // mask addresses in the debug logs
+	debugBodyLimit  int     // max size of the bodies in the debug logs, no limit if <= 0
+	metrics         Metrics // measurements of the requests
 }
 
 // Returns the private key manager connected to the API.
@@ -64,28 +68,37 @@ func NewClient(isMainnet bool, options ...ClientOption) *Client {
 		httpClient:     opts.httpClient,
 		Debug:          opts.debug,
 		isMainnet:      isMainnet,
-		privateKey:     "",
 		defaultAddress: "",
 		Logger:         opts.logger,
 		keyManager:     nil,
+
+		redactAddresses: opts.redactAddresses,
+		debugBodyLimit:  opts.debugBodyLimit,
+		metrics:         opts.metrics,
 	}
 }
 
 // debug prints the debug messages.
 func (client *Client) debug(format string, v ...interface{}) {
 	if client.Debug {
-		client.Logger.Debugf(format, v...)
+		client.Logger.Debug(fmt.Sprintf(format, v...))
 	}
 }
 
-// SetPrivateKey sets the private key for the client.
+// SetPrivateKey sets the private key for the client from a hex string, with or without 0x prefix.
+// The string is not kept, see SetKeyManager to load the key from a keystore, a mnemonic or the environment.
 func (client *Client) SetPrivateKey(privateKey string) error {
-	// always remove an optional 0x/0X prefix
-	privateKey = strings.TrimPrefix(strings.TrimPrefix(privateKey, "0x"), "0X")
-	client.privateKey = privateKey
-	var err error
-	client.keyManager, err = NewPKeyManager(privateKey)
-	return err
+	keyManager, err := NewPKeyManager(privateKey)
+	if err != nil {
+		return err
+	}
+	client.SetKeyManager(keyManager)
+	return nil
+}
+
+// SetKeyManager sets the private key manager signing the actions of the client.
+func (client *Client) SetKeyManager(keyManager *PKeyManager) {
+	client.keyManager = keyManager
 }
 
 // Some methods need public address to gather info (from infoAPI).
@@ -111,34 +124,34 @@ func (client *Client) SetDebugActive() {
 }
 
 // Request sends a POST request to the HyperLiquid API.
+// In debug mode every request is logged with its endpoint, action type, nonce, status and latency.
+// Logged bodies are redacted, see WithRedactAddresses and WithDebugBodyLimit.
+// Requests are recorded by the Metrics of the client, see WithMetrics.
 func (client *Client) Request(endpoint string, payload any) ([]byte, error) {
 	endpoint = strings.TrimPrefix(endpoint, "/") // Remove leading slash if present
 	url := fmt.Sprintf("%s/%s", client.baseUrl, endpoint)
-	client.debug("Request to %s", url)
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		client.debug("Error json.Marshal: %s", err)
 		return nil, err
 	}
-	client.debug("Request payload: %s", string(jsonPayload))
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		client.debug("Error json.Marshal: %s", err)
-		return nil, err
-	}
-	request, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadBytes))
+	request, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		client.debug("Error http.NewRequest: %s", err)
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
+	start := time.Now()
 	response, err := client.httpClient.Do(request)
 	if err != nil {
-		client.debug("Error client.httpClient.Do: %s", err)
+		client.debugRequest(endpoint, jsonPayload, nil, nil, time.Since(start), err)
+		client.recordMetrics(endpoint, jsonPayload, 0, time.Since(start), nil)
 		return nil, err
 	}
 	data, err := io.ReadAll(response.Body)
 	if err != nil {
+		client.debugRequest(endpoint, jsonPayload, response, nil, time.Since(start), err)
+		client.recordMetrics(endpoint, jsonPayload, response.StatusCode, time.Since(start), nil)
 		return nil, err
 	}
 	defer func() {
@@ -149,12 +162,43 @@ func (client *Client) Request(endpoint string, payload any) ([]byte, error) {
 			err = cerr
 		}
 	}()
-	client.debug("response: %#v", response)
-	client.debug("response body: %s", string(data))
-	client.debug("response status code: %d", response.StatusCode)
+	latency := time.Since(start)
+	client.debugRequest(endpoint, jsonPayload, response, data, latency, nil)
+	client.recordMetrics(endpoint, jsonPayload, response.StatusCode, latency, data)
 	if response.StatusCode >= http.StatusBadRequest {
 		// If the status code is 400 or greater, return an error
 		return nil, APIError{Message: fmt.Sprintf("HTTP %d: %s", response.StatusCode, data)}
 	}
 	return data, nil
 }
+
+// debugRequest logs a request and its response as structured fields.
+// Signatures and keys are redacted from the bodies, which are capped to the debug body limit.
+func (client *Client) debugRequest(endpoint string, payload []byte, response *http.Response, body []byte, latency time.Duration, err error) {
+	if !client.Debug {
+		return
+	}
+	actionType, nonce := requestSummary(payload)
+	args := []any{
+		"endpoint", endpoint,
+		"latency", latency,
+		"request", redactBody(payload, client.redactAddresses, client.debugBodyLimit),
+	}
+	if actionType != "" {
+		args = append(args, "action", actionType)
+	}
+	if nonce != 0 {
+		args = append(args, "nonce", nonce)
+	}
+	if response != nil {
+		args = append(args, "status", response.StatusCode)
+	}
+	if body != nil {
+		args = append(args, "response", redactBody(body, client.redactAddresses, client.debugBodyLimit))
+	}
+	if err != nil {
+		client.Logger.Debug("Request failed", append(args, "error", err)...)
+		return
+	}
+	client.Logger.Debug("Request", args...)
+}
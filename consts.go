@@ -5,15 +5,24 @@ const GLOBAL_DEBUG = false // Default debug that is used in all tests
 // API constants
 const MAINNET_API_URL = "https://api.hyperliquid.xyz"
 const TESTNET_API_URL = "https://api.hyperliquid-testnet.xyz"
+const MAINNET_WS_URL = "wss://api.hyperliquid.xyz/ws"
+const TESTNET_WS_URL = "wss://api.hyperliquid-testnet.xyz/ws"
+
+// Logging constants
+const DEFAULT_DEBUG_BODY_LIMIT = 2048 // Max size in bytes of the bodies logged in debug mode
 
 // Execution constants
-const DEFAULT_SLIPPAGE = 0.005 // 0.5% default slippage
-const SPOT_MAX_DECIMALS = 8    // Default decimals for spot
-const PERP_MAX_DECIMALS = 6    // Default decimals for perp
-var USDC_SZ_DECIMALS = 2       // Default decimals for usdc that is used for withdraw
+const DEFAULT_SLIPPAGE = 0.005       // 0.5% default slippage
+const DEFAULT_BOOK_TOLERANCE = 0.001 // 0.1% default tolerance over the book sweep price
+const SPOT_MAX_DECIMALS = 8          // Default decimals for spot
+const PERP_MAX_DECIMALS = 6          // Default decimals for perp
+const PRICE_SIG_FIGS = 5             // Significant figures allowed in prices
+var USDC_SZ_DECIMALS = 2             // Default decimals for usdc that is used for withdraw
+const HYPE_WEI_DECIMALS = 8          // Decimals of HYPE amounts in staking actions
 
 // Signing constants
 const HYPERLIQUID_CHAIN_ID = 1337
 const VERIFYING_CONTRACT = "0x0000000000000000000000000000000000000000"
 const ARBITRUM_CHAIN_ID = 42161
 const ARBITRUM_TESTNET_CHAIN_ID = 421614
+const DEFAULT_DERIVATION_PATH = "m/44'/60'/0'/0/0" // BIP-32 path of the first account of Ethereum wallets
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -112,6 +113,20 @@ func SizeToWire(x float64, _ int) string {
 	return strconv.FormatFloat(x, 'f', -1, 64)
 }
 
+// FloatToUsdInt converts an USDC amount to micro units (6 decimals) used by the exchange actions.
+//
+//	FloatToUsdInt(1.5) // 1500000
+func FloatToUsdInt(x float64) int {
+	return int(math.Round(x * 1e6))
+}
+
+// FloatToWei converts a token amount to its integer representation with the given decimals.
+//
+//	FloatToWei(1.5, HYPE_WEI_DECIMALS) // 150000000
+func FloatToWei(x float64, decimals int) uint64 {
+	return uint64(math.Round(x * math.Pow10(decimals)))
+}
+
 // To sign raw messages via EIP-712
 func StructToMap(strct any) (res map[string]interface{}, err error) {
 	a, err := json.Marshal(strct)
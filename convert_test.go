@@ -109,3 +109,71 @@ func TestConvert_PriceToWire(t *testing.T) {
 		})
 	}
 }
+
+func TestConvert_FloatToUsdInt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    float64
+		expected int
+	}{
+		{
+			name:     "Integer",
+			input:    10,
+			expected: 10000000,
+		},
+		{
+			name:     "Decimals",
+			input:    1.5,
+			expected: 1500000,
+		},
+		{
+			name:     "Float rounding",
+			input:    0.29,
+			expected: 290000,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := FloatToUsdInt(tc.input)
+			if res != tc.expected {
+				t.Errorf("FloatToUsdInt() = %v, want %v", res, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConvert_FloatToWei(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    float64
+		decimals int
+		expected uint64
+	}{
+		{
+			name:     "HYPE",
+			input:    1.5,
+			decimals: HYPE_WEI_DECIMALS,
+			expected: 150000000,
+		},
+		{
+			name:     "HYPE rounding",
+			input:    0.1 + 0.2,
+			decimals: HYPE_WEI_DECIMALS,
+			expected: 30000000,
+		},
+		{
+			name:     "No decimals",
+			input:    42,
+			decimals: 0,
+			expected: 42,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := FloatToWei(tc.input, tc.decimals)
+			if res != tc.expected {
+				t.Errorf("FloatToWei() = %v, want %v", res, tc.expected)
+			}
+		})
+	}
+}
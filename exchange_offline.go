@@ -0,0 +1,129 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NewOfflineExchangeAPI creates an ExchangeAPI that does not make any request on creation.
+// It is meant to sign actions on a machine without network access with SignWithdraw,
+// SignApproveAgent or SignL1. The signed actions are submitted later with SubmitSigned
+// from any ExchangeAPI of the same network.
+// Run SetPrivateKey() to set the private key.
+func NewOfflineExchangeAPI(isMainnet bool, options ...ClientOption) *ExchangeAPI {
+	return &ExchangeAPI{
+		Client:       *NewClient(isMainnet, options...),
+		baseEndpoint: "/exchange",
+		infoAPI: &InfoAPI{
+			baseEndpoint: "/info",
+			Client:       *NewClient(isMainnet, options...),
+		},
+		address:      "",
+		meta:         map[string]AssetInfo{},
+		spotMeta:     map[string]AssetInfo{},
+		nonceManager: defaultNonceManager,
+	}
+}
+
+// newSignedAction serializes a signed action.
+func newSignedAction(action any, nonce uint64, v byte, r [32]byte, s [32]byte, vaultAddress string, expiresAfter *uint64) (*SignedAction, error) {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return nil, err
+	}
+	signed := &SignedAction{
+		Action:       data,
+		Nonce:        nonce,
+		Signature:    ToTypedSig(r, s, v),
+		ExpiresAfter: expiresAfter,
+	}
+	if vaultAddress != "" {
+		signed.VaultAddress = &vaultAddress
+	}
+	return signed, nil
+}
+
+// Sign a withdraw request without sending it
+// The signed action can be submitted with SubmitSigned.
+func (api *ExchangeAPI) SignWithdraw(destination string, amount float64) (*SignedAction, error) {
+	nonce, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := WithdrawAction{
+		Type:        "withdraw3",
+		Destination: destination,
+		Amount:      SizeToWire(amount, USDC_SZ_DECIMALS),
+		Time:        nonce,
+	}
+	signatureChainID, chainType := api.getChainParams()
+	action.HyperliquidChain = chainType
+	action.SignatureChainID = signatureChainID
+	v, r, s, err := api.SignWithdrawAction(action)
+	if err != nil {
+		api.debug("Error signing withdraw action: %s", err)
+		return nil, err
+	}
+	return newSignedAction(action, nonce, v, r, s, "", nil)
+}
+
+// Sign the approval of an agent (API wallet) without sending it
+// agentName can be empty for an unnamed agent.
+// The signed action can be submitted with SubmitSigned.
+func (api *ExchangeAPI) SignApproveAgent(agentAddress string, agentName string) (*SignedAction, error) {
+	nonce, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := ApproveAgentAction{
+		Type:         "approveAgent",
+		AgentAddress: agentAddress,
+		AgentName:    agentName,
+		Nonce:        nonce,
+	}
+	signatureChainID, chainType := api.getChainParams()
+	action.HyperliquidChain = chainType
+	action.SignatureChainID = signatureChainID
+	v, r, s, err := api.SignApproveAgentAction(action)
+	if err != nil {
+		api.debug("Error signing approveAgent action: %s", err)
+		return nil, err
+	}
+	return newSignedAction(action, nonce, v, r, s, "", nil)
+}
+
+// Sign an L1 action without sending it
+// action must serialize with msgpack exactly like the JSON sent to the exchange,
+// see the *Action types of this package.
+// vaultAddress can be empty when it is not used. A nil expiresAfter falls back to the expiry
+// window of the API (see SetExpiresAfter), the action does not expire without window.
+// The signed action can be submitted with SubmitSigned.
+func (api *ExchangeAPI) SignL1(action any, vaultAddress string, expiresAfter *uint64) (*SignedAction, error) {
+	nonce, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	if expiresAfter == nil {
+		expiresAfter = api.l1ExpiresAfter(nonce)
+	}
+	v, r, s, err := api.signL1ActionWithOptions(action, nonce, vaultAddress, expiresAfter)
+	if err != nil {
+		api.debug("Error signing L1 action: %s", err)
+		return nil, err
+	}
+	return newSignedAction(action, nonce, v, r, s, vaultAddress, expiresAfter)
+}
+
+// Submit an action signed with SignWithdraw, SignApproveAgent or SignL1
+// The action is not sent if its nonce is out of the window accepted by the exchange or it has expired.
+func (api *ExchangeAPI) SubmitSigned(signed *SignedAction) (*DefaultExchangeResponse, error) {
+	now := time.Now()
+	if err := ValidateNonce(signed.Nonce, now); err != nil {
+		return nil, err
+	}
+	if signed.ExpiresAfter != nil && uint64(now.UnixMilli()) > *signed.ExpiresAfter {
+		return nil, APIError{Message: fmt.Sprintf("Signed action expired at %d", *signed.ExpiresAfter)}
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, signed.Request())
+}
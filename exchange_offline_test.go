@@ -0,0 +1,122 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const testPrivateKey = "0123456789012345678901234567890123456789012345678901234567890123"
+
+func GetOfflineExchangeAPI(t *testing.T) *ExchangeAPI {
+	api := NewOfflineExchangeAPI(true)
+	if err := api.SetPrivateKey(testPrivateKey); err != nil {
+		t.Fatalf("SetPrivateKey() error = %v", err)
+	}
+	return api
+}
+
+func TestExchangeAPI_SignWithdraw(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	signed, err := api.SignWithdraw("0x5e9ee1089755c3435139848e47e6635505d5a13a", 12.5)
+	if err != nil {
+		t.Fatalf("SignWithdraw() error = %v", err)
+	}
+	// The signed action survives a round trip through JSON
+	data, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded SignedAction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	var action WithdrawAction
+	if err := json.Unmarshal(decoded.Action, &action); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if action.Type != "withdraw3" || action.Amount != "12.5" || action.Time != decoded.Nonce || action.HyperliquidChain != "Mainnet" {
+		t.Errorf("SignWithdraw() action = %+v", action)
+	}
+	types, primaryType, err := UserSignedActionTypes(action.Type)
+	if err != nil {
+		t.Fatalf("UserSignedActionTypes() error = %v", err)
+	}
+	signer, err := RecoverUserSignedActionSigner(action, types, primaryType, true, decoded.Signature)
+	if err != nil {
+		t.Fatalf("RecoverUserSignedActionSigner() error = %v", err)
+	}
+	if signer != api.KeyManager().PublicAddress() {
+		t.Errorf("SignWithdraw() signer = %v, want %v", signer, api.KeyManager().PublicAddressHex())
+	}
+}
+
+func TestExchangeAPI_SignL1(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	action := UpdateLeverageAction{
+		Type:     "updateLeverage",
+		Asset:    1,
+		IsCross:  true,
+		Leverage: 5,
+	}
+	expiresAfter := uint64(time.Now().Add(time.Minute).UnixMilli())
+	signed, err := api.SignL1(action, "", &expiresAfter)
+	if err != nil {
+		t.Fatalf("SignL1() error = %v", err)
+	}
+	if signed.ExpiresAfter == nil || *signed.ExpiresAfter != expiresAfter || signed.VaultAddress != nil {
+		t.Errorf("SignL1() = %+v", signed)
+	}
+	signer, err := RecoverL1ActionSigner(action, signed.Nonce, "", &expiresAfter, true, signed.Signature)
+	if err != nil {
+		t.Fatalf("RecoverL1ActionSigner() error = %v", err)
+	}
+	if signer != api.KeyManager().PublicAddress() {
+		t.Errorf("SignL1() signer = %v, want %v", signer, api.KeyManager().PublicAddressHex())
+	}
+	// The expiration is part of the signed hash
+	withoutExpiry, _ := buildActionHash(action, "", signed.Nonce, nil)
+	withExpiry, _ := buildActionHash(action, "", signed.Nonce, &expiresAfter)
+	if withoutExpiry == withExpiry {
+		t.Errorf("buildActionHash() ignores expiresAfter")
+	}
+
+	// Without expiresAfter, the expiry window of the API applies
+	windowed, err := api.WithExpiresAfter(time.Minute).SignL1(action, "", nil)
+	if err != nil {
+		t.Fatalf("SignL1() error = %v", err)
+	}
+	if windowed.ExpiresAfter == nil || *windowed.ExpiresAfter != windowed.Nonce+60000 {
+		t.Errorf("SignL1() expiresAfter = %v, want nonce + 60000", windowed.ExpiresAfter)
+	}
+	signer, err = RecoverL1ActionSigner(action, windowed.Nonce, "", windowed.ExpiresAfter, true, windowed.Signature)
+	if err != nil || signer != api.KeyManager().PublicAddress() {
+		t.Errorf("SignL1() signer = %v, %v, want %v", signer, err, api.KeyManager().PublicAddressHex())
+	}
+	unbounded, err := api.SignL1(action, "", nil)
+	if err != nil {
+		t.Fatalf("SignL1() error = %v", err)
+	}
+	if unbounded.ExpiresAfter != nil {
+		t.Errorf("SignL1() expiresAfter = %v, want nil without window", *unbounded.ExpiresAfter)
+	}
+}
+
+func TestExchangeAPI_SubmitSignedRejectsStaleActions(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	signed, err := api.SignApproveAgent("0x0000000000000000000000000000000000000001", "bot")
+	if err != nil {
+		t.Fatalf("SignApproveAgent() error = %v", err)
+	}
+	stale := *signed
+	stale.Nonce = uint64(time.Now().Add(-72 * time.Hour).UnixMilli())
+	if _, err := api.SubmitSigned(&stale); err == nil {
+		t.Errorf("SubmitSigned() error = nil, want nonce too old")
+	}
+	expired := *signed
+	expiresAfter := uint64(time.Now().Add(-time.Minute).UnixMilli())
+	expired.ExpiresAfter = &expiresAfter
+	if _, err := api.SubmitSigned(&expired); err == nil {
+		t.Errorf("SubmitSigned() error = nil, want expired action")
+	}
+}
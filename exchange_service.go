@@ -1,9 +1,14 @@
 package hyperliquid
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
@@ -24,10 +29,41 @@ type IExchangeAPI interface {
 	CancelAllOrdersByCoin(coin string) (any, error)
 	CancelAllOrders() (any, error)
 	ClosePosition(coin string) (*OrderResponse, error)
+	MarketOrderFromBook(coin string, size float64, tolerance *float64, clientOID ...string) (*MarketOrderResult, error)
+	MarketOrderSpotFromBook(coin string, size float64, tolerance *float64) (*MarketOrderResult, error)
+	ReconcileOrders(requests []OrderRequest) ([]OrderReconciliation, error)
 
 	// Account management
 	Withdraw(destination string, amount float64) (*WithdrawResponse, error)
 	UpdateLeverage(coin string, isCross bool, leverage int) (any, error)
+	ApproveAgent(agentAddress string, agentName string) (*DefaultExchangeResponse, error)
+
+	// Multi-sig
+	ConvertToMultiSigUser(authorizedUsers []string, threshold int) (*DefaultExchangeResponse, error)
+	MultiSig(multiSigUser string, action any, signatures []RsvSignature, nonce uint64) (*DefaultExchangeResponse, error)
+
+	// Offline signing
+	SignWithdraw(destination string, amount float64) (*SignedAction, error)
+	SignApproveAgent(agentAddress string, agentName string) (*SignedAction, error)
+	SignL1(action any, vaultAddress string, expiresAfter *uint64) (*SignedAction, error)
+	SubmitSigned(signed *SignedAction) (*DefaultExchangeResponse, error)
+
+	// Sub-accounts
+	CreateSubAccount(name string) (*CreateSubAccountResponse, error)
+	SubAccountTransfer(subAccountUser string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error)
+	SubAccountSpotTransfer(subAccountUser string, isDeposit bool, token string, amount float64) (*DefaultExchangeResponse, error)
+
+	// Vaults
+	VaultTransfer(vaultAddress string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error)
+
+	// Referrals
+	SetReferrer(code string) (*DefaultExchangeResponse, error)
+	CreateReferralCode(code string) (*DefaultExchangeResponse, error)
+
+	// Staking
+	StakingDeposit(amount float64) (*DefaultExchangeResponse, error)
+	StakingWithdraw(amount float64) (*DefaultExchangeResponse, error)
+	TokenDelegate(validator string, amount float64, isUndelegate bool) (*DefaultExchangeResponse, error)
 }
 
 // Implement the IExchangeAPI interface.
@@ -38,6 +74,10 @@ type ExchangeAPI struct {
 	baseEndpoint string
 	meta         map[string]AssetInfo
 	spotMeta     map[string]AssetInfo
+	preTrade     bool          // check order sizes with activeAssetData before placing orders
+	nonceManager NonceManager  // nonces of the signed actions
+	expiresAfter time.Duration // expiry window of the L1 actions, 0 for none
+	vaultAddress string        // vault the orders are placed for, empty for the account
 }
 
 // NewExchangeAPI creates a new default ExchangeAPI.
@@ -48,6 +88,7 @@ func NewExchangeAPI(isMainnet bool, options ...ClientOption) *ExchangeAPI {
 		baseEndpoint: "/exchange",
 		infoAPI:      NewInfoAPI(isMainnet, options...),
 		address:      "",
+		nonceManager: defaultNonceManager,
 	}
 	// turn on debug mode if there is an error with /info service
 	meta, err := api.infoAPI.BuildMetaMap()
@@ -76,24 +117,167 @@ func (api *ExchangeAPI) Endpoint() string {
 }
 
 // Helper function to calculate the slippage price based on the market price.
-func (api *ExchangeAPI) SlippagePrice(coin string, isBuy bool, slippage float64) float64 {
+// Returns an error if the market price is not available, the order must not be sent in this case.
+func (api *ExchangeAPI) SlippagePrice(coin string, isBuy bool, slippage float64) (float64, error) {
 	marketPx, err := api.infoAPI.GetMartketPx(coin)
 	if err != nil {
 		api.debug("Error getting market price: %s", err)
-		return 0.0
+		return 0, err
+	}
+	if marketPx <= 0 {
+		return 0, APIError{Message: fmt.Sprintf("Invalid market price %v for %s", marketPx, coin)}
 	}
-	return CalculateSlippage(isBuy, marketPx, slippage)
+	return CalculateSlippage(isBuy, marketPx, slippage), nil
 }
 
 // SlippagePriceSpot is a helper function to calculate the slippage price for a spot coin.
-func (api *ExchangeAPI) SlippagePriceSpot(coin string, isBuy bool, slippage float64) float64 {
+// Returns an error if the market price is not available, the order must not be sent in this case.
+func (api *ExchangeAPI) SlippagePriceSpot(coin string, isBuy bool, slippage float64) (float64, error) {
 	marketPx, err := api.infoAPI.GetSpotMarketPx(coin)
 	if err != nil {
 		api.debug("Error getting market price: %s", err)
-		return 0.0
+		return 0, err
+	}
+	if marketPx <= 0 {
+		return 0, APIError{Message: fmt.Sprintf("Invalid market price %v for %s", marketPx, coin)}
+	}
+	return CalculateSlippage(isBuy, marketPx, slippage), nil
+}
+
+// SetNonceManager sets the manager handing out the nonces of the signed actions.
+// By default a process wide in-memory manager is used, use a FileNonceManager
+// to keep nonces increasing across restarts and processes sharing a key.
+func (api *ExchangeAPI) SetNonceManager(manager NonceManager) {
+	api.nonceManager = manager
+}
+
+// NextNonce returns the next nonce of the signer of the API from its NonceManager.
+// It is needed to sign actions that are submitted later, like the inner actions of MultiSig.
+func (api *ExchangeAPI) NextNonce() (uint64, error) {
+	return api.nextNonce()
+}
+
+// nextNonce returns the next nonce of the signer of the API.
+func (api *ExchangeAPI) nextNonce() (uint64, error) {
+	var signer common.Address
+	if km := api.KeyManager(); km != nil {
+		signer = km.PublicAddress()
 	}
-	slippagePrice := CalculateSlippage(isBuy, marketPx, slippage)
-	return slippagePrice
+	manager := api.nonceManager
+	if manager == nil {
+		manager = defaultNonceManager
+	}
+	return manager.Next(signer)
+}
+
+// SetExpiresAfter sets the expiry window of the L1 actions (orders, cancels, transfers...) sent by the API.
+// The expiry time is the nonce of the action plus window, it is hashed, signed and sent with the action
+// and the exchange rejects the action if it arrives later. A window of 0 disables the expiry.
+// User signed actions (withdraw, approveAgent...) do not support it.
+func (api *ExchangeAPI) SetExpiresAfter(window time.Duration) {
+	api.expiresAfter = window
+}
+
+// WithExpiresAfter returns a copy of the API sending its L1 actions with the expiry window,
+// to bound the actions of a single call:
+//
+//	res, err := api.WithExpiresAfter(2 * time.Second).MarketOrder("ETH", 0.1, nil)
+//
+// The copy is shallow. It shares with the API its key manager, nonce manager (so their nonces
+// never collide), info API, asset metadata, HTTP client, logger and metrics. The settings
+// (account and vault addresses, pre-trade check, debug mode) are copied: changing them on
+// one of the two after the call does not change the other.
+func (api *ExchangeAPI) WithExpiresAfter(window time.Duration) *ExchangeAPI {
+	clone := *api
+	clone.expiresAfter = window
+	return &clone
+}
+
+// l1ExpiresAfter returns the expiry time in milliseconds of an L1 action with the nonce, nil without expiry window.
+func (api *ExchangeAPI) l1ExpiresAfter(nonce uint64) *uint64 {
+	if api.expiresAfter <= 0 {
+		return nil
+	}
+	expiresAfter := nonce + uint64(api.expiresAfter.Milliseconds())
+	return &expiresAfter
+}
+
+// SetVaultAddress sets the vault the orders, cancels, modifies and leverage updates of the API are sent for.
+// The account must be the leader of the vault. The orders, positions and limits looked up by the API
+// (ReconcileOrders, ClosePosition, CancelAllOrders, the pre-trade check) are then the ones of the vault.
+// An empty address trades for the account again.
+func (api *ExchangeAPI) SetVaultAddress(address string) {
+	api.vaultAddress = address
+}
+
+// VaultAddress returns the vault the orders of the API are sent for, empty for none.
+func (api *ExchangeAPI) VaultAddress() string {
+	return api.vaultAddress
+}
+
+// tradingAddress returns the user holding the orders and positions of the API: the vault when one is set, the account otherwise.
+func (api *ExchangeAPI) tradingAddress() string {
+	if api.vaultAddress != "" {
+		return api.vaultAddress
+	}
+	return api.AccountAddress()
+}
+
+// signL1Request signs an L1 action for vaultAddress (empty for none) with the expiry window of the API
+// and returns the request sending it.
+func (api *ExchangeAPI) signL1Request(action any, nonce uint64, vaultAddress string) (*ExchangeRequest, error) {
+	expiresAfter := api.l1ExpiresAfter(nonce)
+	v, r, s, err := api.signL1ActionWithOptions(action, nonce, vaultAddress, expiresAfter)
+	if err != nil {
+		api.debug("Error signing L1 action: %s", err)
+		return nil, err
+	}
+	request := &ExchangeRequest{
+		Action:       action,
+		Nonce:        nonce,
+		Signature:    ToTypedSig(r, s, v),
+		VaultAddress: nil,
+		ExpiresAfter: expiresAfter,
+	}
+	if vaultAddress != "" {
+		request.VaultAddress = &vaultAddress
+	}
+	return request, nil
+}
+
+// SetPreTradeCheck enables or disables the pre-trade check of the perpetual orders.
+// When enabled, orders larger than the max trade size of the account (or of the vault, see SetVaultAddress)
+// are rejected locally with an error instead of being sent to the exchange.
+// The check is done by BulkOrders, so it covers Order, MarketOrder, LimitOrder and MarketOrderFromBook.
+// It costs an additional activeAssetData request per order. Spot orders are not checked.
+func (api *ExchangeAPI) SetPreTradeCheck(enabled bool) {
+	api.preTrade = enabled
+}
+
+// CheckOrderSize returns an error if the order size exceeds the max trade size
+// of the account, or of the vault when one is set, for the coin and side of the order.
+// Reduce only orders are not checked.
+func (api *ExchangeAPI) CheckOrderSize(request OrderRequest) error {
+	if request.ReduceOnly {
+		return nil
+	}
+	data, err := api.infoAPI.GetActiveAssetData(api.tradingAddress(), request.Coin)
+	if err != nil {
+		api.debug("Error GetActiveAssetData: %s", err)
+		return err
+	}
+	maxSz := data.MaxTradeSz(request.IsBuy)
+	if request.Sz > maxSz {
+		side := "sell"
+		if request.IsBuy {
+			side = "buy"
+		}
+		return APIError{Message: fmt.Sprintf(
+			"Order size %v exceeds max %s size %v for %s (leverage %dx %s)",
+			request.Sz, side, maxSz, request.Coin, data.Leverage.Value, data.Leverage.Type,
+		)}
+	}
+	return nil
 }
 
 // Helper function to get the chain params based on the network type.
@@ -105,14 +289,18 @@ func (api *ExchangeAPI) getChainParams() (string, string) {
 }
 
 // Build bulk orders EIP712 message
+// The message is built like the one signed by BulkOrders, with the vault and the expiry window of the API.
 func (api *ExchangeAPI) BuildBulkOrdersEIP712(requests []OrderRequest, grouping Grouping) (apitypes.TypedData, error) {
 	var wires []OrderWire
 	for _, req := range requests {
 		wires = append(wires, OrderRequestToWire(req, api.meta, false))
 	}
-	timestamp := GetNonce()
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
 	action := OrderWiresToOrderAction(wires, grouping)
-	srequest, err := api.BuildEIP712Message(action, timestamp)
+	srequest, err := api.buildL1SignRequest(action, timestamp, api.vaultAddress, api.l1ExpiresAfter(timestamp))
 	if err != nil {
 		api.debug("Error building EIP712 message: %s", err)
 		return apitypes.TypedData{}, err
@@ -130,6 +318,7 @@ func (api *ExchangeAPI) BuildOrderEIP712(request OrderRequest, grouping Grouping
 //
 
 // Place orders in bulk
+// The perpetual orders are checked before being sent when the pre-trade check is enabled, see SetPreTradeCheck.
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#place-an-order
 func (api *ExchangeAPI) BulkOrders(requests []OrderRequest, grouping Grouping, isSpot bool) (*OrderResponse, error) {
 	var wires []OrderWire
@@ -140,20 +329,21 @@ func (api *ExchangeAPI) BulkOrders(requests []OrderRequest, grouping Grouping, i
 		meta = api.meta
 	}
 	for _, req := range requests {
+		if api.preTrade && !isSpot {
+			if err := api.CheckOrderSize(req); err != nil {
+				return nil, err
+			}
+		}
 		wires = append(wires, OrderRequestToWire(req, meta, isSpot))
 	}
-	timestamp := GetNonce()
-	action := OrderWiresToOrderAction(wires, grouping)
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	timestamp, err := api.nextNonce()
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
 		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:       action,
-		Nonce:        timestamp,
-		Signature:    ToTypedSig(r, s, v),
-		VaultAddress: nil,
+	action := OrderWiresToOrderAction(wires, grouping)
+	request, err := api.signL1Request(action, timestamp, api.vaultAddress)
+	if err != nil {
+		return nil, err
 	}
 	return MakeUniversalRequest[OrderResponse](api, request)
 }
@@ -161,43 +351,56 @@ func (api *ExchangeAPI) BulkOrders(requests []OrderRequest, grouping Grouping, i
 // Cancel order(s)
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#cancel-order-s
 func (api *ExchangeAPI) BulkCancelOrders(cancels []CancelOidWire) (*OrderResponse, error) {
-	timestamp := GetNonce()
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
 	action := CancelOidOrderAction{
 		Type:    "cancel",
 		Cancels: cancels,
 	}
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	request, err := api.signL1Request(action, timestamp, api.vaultAddress)
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
 		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:       action,
-		Nonce:        timestamp,
-		Signature:    ToTypedSig(r, s, v),
-		VaultAddress: nil,
-	}
 	return MakeUniversalRequest[OrderResponse](api, request)
 }
 
 // Bulk modify orders
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#modify-multiple-orders
 func (api *ExchangeAPI) BulkModifyOrders(modifyRequests []ModifyOrderRequest, isSpot bool) (*OrderResponse, error) {
+	meta := api.meta
+	if isSpot {
+		meta = api.spotMeta
+	}
+	modifies := make([]ModifyOrderWire, 0, len(modifyRequests))
+	for _, req := range modifyRequests {
+		order := OrderRequest{
+			Coin:       req.Coin,
+			IsBuy:      req.IsBuy,
+			Sz:         req.Sz,
+			LimitPx:    req.LimitPx,
+			OrderType:  req.OrderType,
+			ReduceOnly: req.ReduceOnly,
+			Cloid:      req.Cloid,
+		}
+		modifies = append(modifies, ModifyOrderWire{
+			OrderId: req.OrderId,
+			Order:   OrderRequestToWire(order, meta, isSpot),
+		})
+	}
 	action := ModifyOrderAction{
 		Type:     "batchModify",
-		Modifies: modifyRequests,
+		Modifies: modifies,
 	}
 
-	timestamp := GetNonce()
-	vVal, rVal, sVal, signErr := api.SignL1Action(action, timestamp)
-	if signErr != nil {
-		return nil, signErr
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:       action,
-		Nonce:        timestamp,
-		Signature:    ToTypedSig(rVal, sVal, vVal),
-		VaultAddress: nil,
+	request, err := api.signL1Request(action, timestamp, api.vaultAddress)
+	if err != nil {
+		return nil, err
 	}
 	return MakeUniversalRequest[OrderResponse](api, request)
 }
@@ -205,7 +408,10 @@ func (api *ExchangeAPI) BulkModifyOrders(modifyRequests []ModifyOrderRequest, is
 // Cancel exact order by Client Order Id
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#cancel-order-s-by-cloid
 func (api *ExchangeAPI) CancelOrderByCloid(coin string, clientOID string) (*OrderResponse, error) {
-	timestamp := GetNonce()
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
 	action := CancelCloidOrderAction{
 		Type: "cancelByCloid",
 		Cancels: []CancelCloidWire{
@@ -215,69 +421,357 @@ func (api *ExchangeAPI) CancelOrderByCloid(coin string, clientOID string) (*Orde
 			},
 		},
 	}
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	request, err := api.signL1Request(action, timestamp, api.vaultAddress)
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
 		return nil, err
 	}
-	request := ExchangeRequest{
-		Action:       action,
-		Nonce:        timestamp,
-		Signature:    ToTypedSig(r, s, v),
-		VaultAddress: nil,
-	}
 	return MakeUniversalRequest[OrderResponse](api, request)
 }
 
 // Update leverage for a coin
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#update-leverage
 func (api *ExchangeAPI) UpdateLeverage(coin string, isCross bool, leverage int) (*DefaultExchangeResponse, error) {
-	timestamp := GetNonce()
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
 	action := UpdateLeverageAction{
 		Type:     "updateLeverage",
 		Asset:    api.meta[coin].AssetId,
 		IsCross:  isCross,
 		Leverage: leverage,
 	}
-	v, r, s, err := api.SignL1Action(action, timestamp)
+	request, err := api.signL1Request(action, timestamp, api.vaultAddress)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Create a sub-account
+// Returns the address of the new sub-account in Response.Data
+func (api *ExchangeAPI) CreateSubAccount(name string) (*CreateSubAccountResponse, error) {
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := CreateSubAccountAction{
+		Type: "createSubAccount",
+		Name: name,
+	}
+	request, err := api.signL1Request(action, timestamp, "")
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[CreateSubAccountResponse](api, request)
+}
+
+// Transfer USDC between the master account and a sub-account perp balance
+// isDeposit=true moves funds from the master account to the sub-account
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#deposit-or-withdraw-from-a-subaccount
+func (api *ExchangeAPI) SubAccountTransfer(subAccountUser string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error) {
+	timestamp, err := api.nextNonce()
 	if err != nil {
-		api.debug("Error signing L1 action: %s", err)
+		return nil, err
+	}
+	action := SubAccountTransferAction{
+		Type:           "subAccountTransfer",
+		SubAccountUser: subAccountUser,
+		IsDeposit:      isDeposit,
+		Usd:            FloatToUsdInt(usd),
+	}
+	request, err := api.signL1Request(action, timestamp, "")
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Transfer a spot token between the master account and a sub-account spot balance
+// The token has the format "<name>:<tokenId>", e.g. "PURR:0xc4bf3f870c0e9465323c0b6ed28096c2"
+// isDeposit=true moves funds from the master account to the sub-account
+func (api *ExchangeAPI) SubAccountSpotTransfer(subAccountUser string, isDeposit bool, token string, amount float64) (*DefaultExchangeResponse, error) {
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := SubAccountSpotTransferAction{
+		Type:           "subAccountSpotTransfer",
+		SubAccountUser: subAccountUser,
+		IsDeposit:      isDeposit,
+		Token:          token,
+		Amount:         SizeToWire(amount, 0),
+	}
+	request, err := api.signL1Request(action, timestamp, "")
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Deposit USDC into a vault or withdraw from it
+// Withdrawals are rejected while the deposit is locked, see GetUserVaultEquities
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#deposit-or-withdraw-from-a-vault
+func (api *ExchangeAPI) VaultTransfer(vaultAddress string, isDeposit bool, usd float64) (*DefaultExchangeResponse, error) {
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := VaultTransferAction{
+		Type:         "vaultTransfer",
+		VaultAddress: vaultAddress,
+		IsDeposit:    isDeposit,
+		Usd:          FloatToUsdInt(usd),
+	}
+	request, err := api.signL1Request(action, timestamp, "")
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Attach the account to a referrer using its referral code
+// The referrer can only be set once, before the account has traded a significant volume
+func (api *ExchangeAPI) SetReferrer(code string) (*DefaultExchangeResponse, error) {
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := SetReferrerAction{
+		Type: "setReferrer",
+		Code: code,
+	}
+	request, err := api.signL1Request(action, timestamp, "")
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Create the referral code of the account (registerReferrer action)
+// The account must be in the ReferrerStageNeedToCreateCode stage, see GetReferralState
+func (api *ExchangeAPI) CreateReferralCode(code string) (*DefaultExchangeResponse, error) {
+	timestamp, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := RegisterReferrerAction{
+		Type: "registerReferrer",
+		Code: code,
+	}
+	request, err := api.signL1Request(action, timestamp, "")
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Initiate a withdraw request
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#initiate-a-withdrawal-request
+func (api *ExchangeAPI) Withdraw(destination string, amount float64) (*WithdrawResponse, error) {
+	signed, err := api.SignWithdraw(destination, amount)
+	if err != nil {
+		return nil, err
+	}
+	return MakeUniversalRequest[WithdrawResponse](api, signed.Request())
+}
+
+// Convert the account to a multi-sig user
+// Once converted, actions of the account must be sent with MultiSig and signed by
+// at least threshold of the authorized users.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/hypercore/multi-sig
+func (api *ExchangeAPI) ConvertToMultiSigUser(authorizedUsers []string, threshold int) (*DefaultExchangeResponse, error) {
+	signers, err := multiSigSignersJSON(authorizedUsers, threshold)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	signatureChainID, chainType := api.getChainParams()
+	action := ConvertToMultiSigUserAction{
+		Type:             "convertToMultiSigUser",
+		SignatureChainID: signatureChainID,
+		HyperliquidChain: chainType,
+		Signers:          signers,
+		Nonce:            nonce,
+	}
+	v, r, s, err := api.SignConvertToMultiSigUserAction(action)
+	if err != nil {
+		api.debug("Error signing convertToMultiSigUser action: %s", err)
+		return nil, err
+	}
+	request := &ExchangeRequest{
+		Action:       action,
+		Nonce:        nonce,
+		Signature:    ToTypedSig(r, s, v),
+		VaultAddress: nil,
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// multiSigSignersJSON returns the MultiSigSigners JSON of the sorted authorized users,
+// serialized like json.dumps of the Python SDK, with ", " and ": " separators.
+func multiSigSignersJSON(authorizedUsers []string, threshold int) (string, error) {
+	users := slices.Clone(authorizedUsers)
+	slices.Sort(users)
+	quoted := make([]string, len(users))
+	for i, user := range users {
+		data, err := json.Marshal(user)
+		if err != nil {
+			return "", err
+		}
+		quoted[i] = string(data)
+	}
+	return fmt.Sprintf(`{"authorizedUsers": [%s], "threshold": %d}`, strings.Join(quoted, ", "), threshold), nil
+}
+
+// Submit an action of the multi-sig account multiSigUser
+// The account of the API is the outer signer, it must be one of the authorized users.
+// signatures are produced by the authorized users with SignMultiSigL1Action or
+// SignMultiSigUserSignedAction over the same action and nonce.
+//
+// Example:
+//
+//	nonce, _ := outer.NextNonce()
+//	var signatures []RsvSignature
+//	for _, signer := range signers {
+//		sig, _ := signer.SignMultiSigL1Action(action, multiSigUser, outer.AccountAddress(), nonce)
+//		signatures = append(signatures, sig)
+//	}
+//	res, err := outer.MultiSig(multiSigUser, action, signatures, nonce)
+func (api *ExchangeAPI) MultiSig(multiSigUser string, action any, signatures []RsvSignature, nonce uint64) (*DefaultExchangeResponse, error) {
+	if api.KeyManager() == nil {
+		return nil, APIError{Message: "Private key is not set"}
+	}
+	signatureChainID, _ := api.getChainParams()
+	multiSigAction := MultiSigAction{
+		Type:             "multiSig",
+		SignatureChainID: signatureChainID,
+		Signatures:       signatures,
+		Payload: MultiSigPayload{
+			MultiSigUser: strings.ToLower(multiSigUser),
+			OuterSigner:  strings.ToLower(api.KeyManager().PublicAddressHex()),
+			Action:       action,
+		},
+	}
+	v, r, s, err := api.SignMultiSigEnvelope(multiSigAction, nonce)
+	if err != nil {
+		api.debug("Error signing multiSig action: %s", err)
+		return nil, err
+	}
+	request := &ExchangeRequest{
+		Action:       multiSigAction,
+		Nonce:        nonce,
+		Signature:    ToTypedSig(r, s, v),
+		VaultAddress: nil,
+		ExpiresAfter: api.l1ExpiresAfter(nonce),
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Approve an agent (API wallet) to sign L1 actions for the account
+// agentName can be empty for an unnamed agent. Approving a new agent with the name
+// of an existing one replaces it.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#approve-an-api-wallet
+func (api *ExchangeAPI) ApproveAgent(agentAddress string, agentName string) (*DefaultExchangeResponse, error) {
+	signed, err := api.SignApproveAgent(agentAddress, agentName)
+	if err != nil {
+		return nil, err
+	}
+	return api.SubmitSigned(signed)
+}
+
+// Transfer HYPE from the spot balance to the staking balance (cDeposit)
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#deposit-into-staking
+func (api *ExchangeAPI) StakingDeposit(amount float64) (*DefaultExchangeResponse, error) {
+	nonce, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := CDepositAction{
+		Type:  "cDeposit",
+		Wei:   FloatToWei(amount, HYPE_WEI_DECIMALS),
+		Nonce: nonce,
+	}
+	signatureChainID, chainType := api.getChainParams()
+	action.HyperliquidChain = chainType
+	action.SignatureChainID = signatureChainID
+	v, r, s, err := api.SignCDepositAction(action)
+	if err != nil {
+		api.debug("Error signing cDeposit action: %s", err)
 		return nil, err
 	}
 	request := ExchangeRequest{
 		Action:       action,
-		Nonce:        timestamp,
+		Nonce:        nonce,
 		Signature:    ToTypedSig(r, s, v),
 		VaultAddress: nil,
 	}
 	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
 }
 
-// Initiate a withdraw request
-// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#initiate-a-withdrawal-request
-func (api *ExchangeAPI) Withdraw(destination string, amount float64) (*WithdrawResponse, error) {
-	nonce := GetNonce()
-	action := WithdrawAction{
-		Type:        "withdraw3",
-		Destination: destination,
-		Amount:      SizeToWire(amount, USDC_SZ_DECIMALS),
-		Time:        nonce,
+// Transfer HYPE from the staking balance to the spot balance (cWithdraw)
+// The withdrawal goes through a 7 days unstaking queue, see GetDelegatorSummary
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#withdraw-from-staking
+func (api *ExchangeAPI) StakingWithdraw(amount float64) (*DefaultExchangeResponse, error) {
+	nonce, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := CWithdrawAction{
+		Type:  "cWithdraw",
+		Wei:   FloatToWei(amount, HYPE_WEI_DECIMALS),
+		Nonce: nonce,
 	}
 	signatureChainID, chainType := api.getChainParams()
 	action.HyperliquidChain = chainType
 	action.SignatureChainID = signatureChainID
-	v, r, s, err := api.SignWithdrawAction(action)
+	v, r, s, err := api.SignCWithdrawAction(action)
 	if err != nil {
-		api.debug("Error signing withdraw action: %s", err)
+		api.debug("Error signing cWithdraw action: %s", err)
 		return nil, err
 	}
-	request := &ExchangeRequest{
+	request := ExchangeRequest{
+		Action:       action,
+		Nonce:        nonce,
+		Signature:    ToTypedSig(r, s, v),
+		VaultAddress: nil,
+	}
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
+}
+
+// Delegate HYPE from the staking balance to a validator or undelegate it
+// Delegations are locked for one day before they can be undelegated
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/exchange-endpoint#delegate-or-undelegate-stake-from-validator
+func (api *ExchangeAPI) TokenDelegate(validator string, amount float64, isUndelegate bool) (*DefaultExchangeResponse, error) {
+	nonce, err := api.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	action := TokenDelegateAction{
+		Type:         "tokenDelegate",
+		Validator:    validator,
+		Wei:          FloatToWei(amount, HYPE_WEI_DECIMALS),
+		IsUndelegate: isUndelegate,
+		Nonce:        nonce,
+	}
+	signatureChainID, chainType := api.getChainParams()
+	action.HyperliquidChain = chainType
+	action.SignatureChainID = signatureChainID
+	v, r, s, err := api.SignTokenDelegateAction(action)
+	if err != nil {
+		api.debug("Error signing tokenDelegate action: %s", err)
+		return nil, err
+	}
+	request := ExchangeRequest{
 		Action:       action,
 		Nonce:        nonce,
 		Signature:    ToTypedSig(r, s, v),
 		VaultAddress: nil,
 	}
-	return MakeUniversalRequest[WithdrawResponse](api, request)
+	return MakeUniversalRequest[DefaultExchangeResponse](api, request)
 }
 
 //
@@ -299,7 +793,10 @@ func (api *ExchangeAPI) Order(request OrderRequest, grouping Grouping) (*OrderRe
 func (api *ExchangeAPI) MarketOrder(coin string, size float64, slippage *float64, clientOID ...string) (*OrderResponse, error) {
 	slpg := GetSlippage(slippage)
 	isBuy := IsBuy(size)
-	finalPx := api.SlippagePrice(coin, isBuy, slpg)
+	finalPx, err := api.SlippagePrice(coin, isBuy, slpg)
+	if err != nil {
+		return nil, err
+	}
 	orderType := OrderType{
 		Limit: &LimitOrderType{
 			Tif: TifIoc,
@@ -330,7 +827,10 @@ func (api *ExchangeAPI) MarketOrder(coin string, size float64, slippage *float64
 func (api *ExchangeAPI) MarketOrderSpot(coin string, size float64, slippage *float64) (*OrderResponse, error) {
 	slpg := GetSlippage(slippage)
 	isBuy := IsBuy(size)
-	finalPx := api.SlippagePriceSpot(coin, isBuy, slpg)
+	finalPx, err := api.SlippagePriceSpot(coin, isBuy, slpg)
+	if err != nil {
+		return nil, err
+	}
 	orderType := OrderType{
 		Limit: &LimitOrderType{
 			Tif: TifIoc,
@@ -347,6 +847,90 @@ func (api *ExchangeAPI) MarketOrderSpot(coin string, size float64, slippage *flo
 	return api.OrderSpot(orderRequest, GroupingNa)
 }
 
+// Open a market order priced from the current L2 book.
+// The book is swept for the order size and the limit price is the worst level reached
+// moved by tolerance (DEFAULT_BOOK_TOLERANCE if nil), so thin books are not overpaid
+// and volatile books still fill. The order is not sent if the book cannot fill the size.
+// Size determines the amount of the coin to buy/sell.
+//
+//	MarketOrderFromBook("BTC", 0.1, nil) // Buy 0.1 BTC
+//	MarketOrderFromBook("BTC", -0.1, &tolerance) // Sell 0.1 BTC
+func (api *ExchangeAPI) MarketOrderFromBook(coin string, size float64, tolerance *float64, clientOID ...string) (*MarketOrderResult, error) {
+	return api.marketOrderFromBook(coin, coin, size, tolerance, false, clientOID...)
+}
+
+// MarketOrderSpotFromBook is MarketOrderFromBook for a spot coin.
+//
+//	MarketOrderSpotFromBook("HYPE", 0.1, nil) // Buy 0.1 HYPE
+func (api *ExchangeAPI) MarketOrderSpotFromBook(coin string, size float64, tolerance *float64) (*MarketOrderResult, error) {
+	spotName := api.infoAPI.spotMeta[coin].SpotName
+	if spotName == "" {
+		return nil, APIError{Message: fmt.Sprintf("Unknown spot coin %s", coin)}
+	}
+	return api.marketOrderFromBook(coin, spotName, size, tolerance, true)
+}
+
+// marketOrderFromBook sweeps the book of bookCoin and sends an IOC order for coin.
+func (api *ExchangeAPI) marketOrderFromBook(coin string, bookCoin string, size float64, tolerance *float64, isSpot bool, clientOID ...string) (*MarketOrderResult, error) {
+	tol := DEFAULT_BOOK_TOLERANCE
+	if tolerance != nil {
+		tol = *tolerance
+	}
+	isBuy := IsBuy(size)
+	book, err := api.infoAPI.GetL2Book(bookCoin, 0, 0)
+	if err != nil {
+		api.debug("Error GetL2Book: %s", err)
+		return nil, err
+	}
+	midPx := book.Mid()
+	if midPx == 0 {
+		return nil, APIError{Message: fmt.Sprintf("Cannot compute mid price of %s book", coin)}
+	}
+	expectedPx, worstPx, err := book.AverageFillPrice(isBuy, math.Abs(size))
+	if err != nil {
+		return nil, err
+	}
+	orderRequest := OrderRequest{
+		Coin:    coin,
+		IsBuy:   isBuy,
+		Sz:      math.Abs(size),
+		LimitPx: CalculateSlippage(isBuy, worstPx, tol),
+		OrderType: OrderType{
+			Limit: &LimitOrderType{
+				Tif: TifIoc,
+			},
+		},
+		ReduceOnly: false,
+	}
+	if len(clientOID) > 0 {
+		orderRequest.Cloid = clientOID[0]
+	}
+	var response *OrderResponse
+	if isSpot {
+		response, err = api.OrderSpot(orderRequest, GroupingNa)
+	} else {
+		response, err = api.Order(orderRequest, GroupingNa)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &MarketOrderResult{
+		Response:            response,
+		MidPx:               midPx,
+		LimitPx:             orderRequest.LimitPx,
+		ExpectedAvgPx:       expectedPx,
+		ExpectedSlippageBps: slippageBps(isBuy, midPx, expectedPx),
+	}
+	for _, status := range response.Response.Data.Statuses {
+		if status.Filled.TotalSz > 0 {
+			result.FilledSz = status.Filled.TotalSz
+			result.RealizedAvgPx = status.Filled.AvgPx
+			result.RealizedSlippageBps = slippageBps(isBuy, midPx, status.Filled.AvgPx)
+		}
+	}
+	return result, nil
+}
+
 // Open a limit order.
 // Order type can be Gtc, Ioc, Alo.
 // Size determines the amount of the coin to buy/sell.
@@ -379,7 +963,7 @@ func (api *ExchangeAPI) LimitOrder(orderType string, coin string, size float64,
 func (api *ExchangeAPI) ClosePosition(coin string) (*OrderResponse, error) {
 	// Get all positions and find the one for the coin
 	// Then just make MarketOpen with the reverse size
-	state, err := api.infoAPI.GetUserState(api.AccountAddress())
+	state, err := api.infoAPI.GetUserState(api.tradingAddress())
 	if err != nil {
 		api.debug("Error GetUserState: %s", err)
 		return nil, err
@@ -396,7 +980,10 @@ func (api *ExchangeAPI) ClosePosition(coin string) (*OrderResponse, error) {
 		size := item.Szi
 		// reverse the position to close
 		isBuy := !IsBuy(size)
-		finalPx := api.SlippagePrice(coin, isBuy, slippage)
+		finalPx, err := api.SlippagePrice(coin, isBuy, slippage)
+		if err != nil {
+			return nil, err
+		}
 		orderType := OrderType{
 			Limit: &LimitOrderType{
 				Tif: "Ioc",
@@ -427,7 +1014,7 @@ func (api *ExchangeAPI) CancelOrderByOID(coin string, orderID int64) (*OrderResp
 
 // Cancel all orders for a given coin
 func (api *ExchangeAPI) CancelAllOrdersByCoin(coin string) (*OrderResponse, error) {
-	orders, err := api.infoAPI.GetOpenOrders(api.AccountAddress())
+	orders, err := api.infoAPI.GetOpenOrders(api.tradingAddress())
 	if err != nil {
 		api.debug("Error getting orders: %s", err)
 		return nil, err
@@ -444,7 +1031,7 @@ func (api *ExchangeAPI) CancelAllOrdersByCoin(coin string) (*OrderResponse, erro
 
 // Cancel all open orders
 func (api *ExchangeAPI) CancelAllOrders() (*OrderResponse, error) {
-	orders, err := api.infoAPI.GetOpenOrders(api.AccountAddress())
+	orders, err := api.infoAPI.GetOpenOrders(api.tradingAddress())
 	if err != nil {
 		api.debug("Error getting orders: %s", err)
 		return nil, err
@@ -458,3 +1045,29 @@ func (api *ExchangeAPI) CancelAllOrders() (*OrderResponse, error) {
 	}
 	return api.BulkCancelOrders(cancels)
 }
+
+// Resolve the outcome of orders whose placement result is unknown,
+// e.g. after a timeout or transport error on BulkOrders.
+// Every request must have a Cloid, since the oid is only known once the exchange accepted the order.
+// The orders are looked up for the vault of the API when one is set, see SetVaultAddress.
+// Orders with Placed=false were never received by the exchange and can be safely resent.
+func (api *ExchangeAPI) ReconcileOrders(requests []OrderRequest) ([]OrderReconciliation, error) {
+	results := make([]OrderReconciliation, 0, len(requests))
+	for _, req := range requests {
+		if req.Cloid == "" {
+			return nil, APIError{Message: fmt.Sprintf("Cannot reconcile %s order without cloid", req.Coin)}
+		}
+		res, err := api.infoAPI.GetOrderStatusByCloid(api.tradingAddress(), req.Cloid)
+		if err != nil {
+			api.debug("Error GetOrderStatusByCloid: %s", err)
+			return nil, err
+		}
+		reconciliation := OrderReconciliation{Cloid: req.Cloid}
+		if !res.IsUnknown() {
+			reconciliation.Placed = true
+			reconciliation.Status = res.Order
+		}
+		results = append(results, reconciliation)
+	}
+	return results, nil
+}
@@ -0,0 +1,273 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestExchangeAPI returns an offline API whose /info and /exchange requests are answered by respond,
+// with the endpoint ("info" or "exchange") and the body of the request.
+func newTestExchangeAPI(t *testing.T, respond func(endpoint string, body []byte) string) *ExchangeAPI {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte(respond(strings.TrimLeft(r.URL.Path, "/"), body)))
+	}))
+	t.Cleanup(server.Close)
+	api := GetOfflineExchangeAPI(t)
+	api.baseUrl = server.URL
+	api.infoAPI.baseUrl = server.URL
+	api.meta["ETH"] = AssetInfo{AssetId: 4, SzDecimals: 4}
+	return api
+}
+
+func TestExchangeAPI_BulkModifyOrdersSendsWire(t *testing.T) {
+	var body []byte
+	api := newTestExchangeAPI(t, func(endpoint string, request []byte) string {
+		body = request
+		return `{"status":"ok","response":{"type":"default"}}`
+	})
+	_, err := api.BulkModifyOrders([]ModifyOrderRequest{{
+		OrderId:   123,
+		Coin:      "ETH",
+		IsBuy:     true,
+		Sz:        0.0147,
+		LimitPx:   1670.1,
+		OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}},
+	}}, false)
+	if err != nil {
+		t.Fatalf("BulkModifyOrders() error = %v", err)
+	}
+
+	// The orders are sent in wire format, like the orders of BulkOrders
+	want := `"modifies":[{"oid":123,"order":{"a":4,"b":true,"p":"1670.1","s":"0.0147","r":false,"t":{"limit":{"tif":"Gtc"}}}}]`
+	if !strings.Contains(string(body), want) {
+		t.Errorf("BulkModifyOrders() body = %s, want %s", body, want)
+	}
+	// The signature covers the action that is sent
+	var request struct {
+		Action    ModifyOrderAction `json:"action"`
+		Nonce     uint64            `json:"nonce"`
+		Signature RsvSignature      `json:"signature"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	signer, err := RecoverL1ActionSigner(request.Action, request.Nonce, "", nil, true, request.Signature)
+	if err != nil {
+		t.Fatalf("RecoverL1ActionSigner() error = %v", err)
+	}
+	if signer != api.KeyManager().PublicAddress() {
+		t.Errorf("BulkModifyOrders() signer = %v, want %v", signer, api.KeyManager().PublicAddress())
+	}
+}
+
+func TestExchangeAPI_VaultAddress(t *testing.T) {
+	vault := "0x1719884eb866cb12b2287399b15f7db5e7d775ea"
+	users := make(map[string]string)
+	var exchangeBody []byte
+	api := newTestExchangeAPI(t, func(endpoint string, body []byte) string {
+		if endpoint == "exchange" {
+			exchangeBody = body
+			return `{"status":"ok","response":{"type":"order","data":{"statuses":[{"resting":{"oid":1}}]}}}`
+		}
+		var request struct {
+			Type string `json:"type"`
+			User string `json:"user"`
+		}
+		json.Unmarshal(body, &request)
+		users[request.Type] = request.User
+		return `{"status":"unknownOid"}`
+	})
+	api.SetAccountAddress(api.KeyManager().PublicAddressHex())
+	api.SetVaultAddress(vault)
+
+	// Orders are signed and sent for the vault
+	order := OrderRequest{Coin: "ETH", IsBuy: true, Sz: 0.1, LimitPx: 2000, OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}}, Cloid: "0x00000000000000000000000000000001"}
+	if _, err := api.Order(order, GroupingNa); err != nil {
+		t.Fatalf("Order() error = %v", err)
+	}
+	var request struct {
+		Action       PlaceOrderAction `json:"action"`
+		Nonce        uint64           `json:"nonce"`
+		Signature    RsvSignature     `json:"signature"`
+		VaultAddress string           `json:"vaultAddress"`
+	}
+	if err := json.Unmarshal(exchangeBody, &request); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if request.VaultAddress != vault {
+		t.Errorf("Order() vaultAddress = %q, want %q", request.VaultAddress, vault)
+	}
+	signer, err := RecoverL1ActionSigner(request.Action, request.Nonce, vault, nil, true, request.Signature)
+	if err != nil || signer != api.KeyManager().PublicAddress() {
+		t.Errorf("Order() signer = %v, %v, want %v", signer, err, api.KeyManager().PublicAddress())
+	}
+
+	// Orders placed for the vault are reconciled with the orders of the vault
+	if _, err := api.ReconcileOrders([]OrderRequest{order}); err != nil {
+		t.Fatalf("ReconcileOrders() error = %v", err)
+	}
+	if users["orderStatus"] != vault {
+		t.Errorf("ReconcileOrders() user = %q, want %q", users["orderStatus"], vault)
+	}
+
+	// Without vault, the account is used
+	api.SetVaultAddress("")
+	if _, err := api.ReconcileOrders([]OrderRequest{order}); err != nil {
+		t.Fatalf("ReconcileOrders() error = %v", err)
+	}
+	if users["orderStatus"] != api.AccountAddress() {
+		t.Errorf("ReconcileOrders() user = %q, want %q", users["orderStatus"], api.AccountAddress())
+	}
+}
+
+func TestExchangeAPI_PreTradeCheck(t *testing.T) {
+	vault := "0x1719884eb866cb12b2287399b15f7db5e7d775ea"
+	var user string
+	sent := 0
+	api := newTestExchangeAPI(t, func(endpoint string, body []byte) string {
+		if endpoint == "exchange" {
+			sent++
+			return `{"status":"ok","response":{"type":"order","data":{"statuses":[{"resting":{"oid":1}}]}}}`
+		}
+		var request struct {
+			User string `json:"user"`
+		}
+		json.Unmarshal(body, &request)
+		user = request.User
+		return `{"user": "` + request.User + `", "coin": "ETH", "leverage": {"type": "cross", "value": 20}, "maxTradeSzs": ["1.5", "2.5"], "availableToTrade": ["3000.0", "5000.0"], "markPx": "2000.0"}`
+	})
+	api.SetPreTradeCheck(true)
+	api.SetVaultAddress(vault)
+	order := func(sz float64) OrderRequest {
+		return OrderRequest{Coin: "ETH", IsBuy: true, Sz: sz, LimitPx: 2000, OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}}}
+	}
+
+	// Every order path is checked, with the sizes of the vault
+	if _, err := api.Order(order(2), GroupingNa); err == nil {
+		t.Errorf("Order() error = nil, want oversized order error")
+	}
+	if user != vault {
+		t.Errorf("activeAssetData user = %q, want %q", user, vault)
+	}
+	if _, err := api.BulkOrders([]OrderRequest{order(1), order(2)}, GroupingNa, false); err == nil {
+		t.Errorf("BulkOrders() error = nil, want oversized order error")
+	}
+	if _, err := api.LimitOrder(TifGtc, "ETH", 2, 2000, false); err == nil {
+		t.Errorf("LimitOrder() error = nil, want oversized order error")
+	}
+	if sent != 0 {
+		t.Errorf("%d oversized orders sent, want 0", sent)
+	}
+
+	// Orders within the max size and reduce only orders are sent
+	reduceOnly := order(2)
+	reduceOnly.ReduceOnly = true
+	if _, err := api.BulkOrders([]OrderRequest{order(1), reduceOnly}, GroupingNa, false); err != nil {
+		t.Errorf("BulkOrders() error = %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("%d orders sent, want 1", sent)
+	}
+}
+
+// orderLimitPx returns the limit price of the single order of a placed order request.
+func orderLimitPx(t *testing.T, body []byte) string {
+	var request struct {
+		Action PlaceOrderAction `json:"action"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil || len(request.Action.Orders) != 1 {
+		t.Errorf("unexpected order request %s", body)
+		return ""
+	}
+	return request.Action.Orders[0].LimitPx
+}
+
+func TestExchangeAPI_MarketOrderLimitRoundsAwayFromMid(t *testing.T) {
+	var limitPx string
+	api := newTestExchangeAPI(t, func(endpoint string, body []byte) string {
+		if endpoint == "info" {
+			return `{"ETH": "1234.56"}`
+		}
+		limitPx = orderLimitPx(t, body)
+		return `{"status":"ok","response":{"type":"order","data":{"statuses":[{"filled":{"oid":1,"totalSz":"1","avgPx":"1234.6"}}]}}}`
+	})
+	tests := []struct {
+		size      float64
+		slippage  float64
+		wantLimit string
+	}{
+		{size: 1, slippage: 0.01, wantLimit: "1247"},    // 1246.9056, nearest is 1246.9
+		{size: -1, slippage: 0.02, wantLimit: "1209.8"}, // 1209.8688, nearest is 1209.9
+		{size: 1, slippage: 0.05, wantLimit: "1296.3"},  // 1296.288
+		{size: -1, slippage: 0.05, wantLimit: "1172.8"}, // 1172.832
+	}
+	for _, tt := range tests {
+		if _, err := api.MarketOrder("ETH", tt.size, &tt.slippage); err != nil {
+			t.Fatalf("MarketOrder(%v) error = %v", tt.size, err)
+		}
+		if limitPx != tt.wantLimit {
+			t.Errorf("MarketOrder(%v, %v) limit = %v, want %v", tt.size, tt.slippage, limitPx, tt.wantLimit)
+		}
+	}
+}
+
+func TestExchangeAPI_MarketOrderFromBookLimitCoversBook(t *testing.T) {
+	var limitPx string
+	api := newTestExchangeAPI(t, func(endpoint string, body []byte) string {
+		if endpoint == "info" {
+			return `{"coin": "ETH", "time": 1700000000000, "levels": [
+				[{"px": "1234.59", "sz": "1", "n": 1}], [{"px": "1234.61", "sz": "0.5", "n": 1}, {"px": "1234.73", "sz": "1", "n": 1}]
+			]}`
+		}
+		limitPx = orderLimitPx(t, body)
+		return `{"status":"ok","response":{"type":"order","data":{"statuses":[{"filled":{"oid":1,"totalSz":"1","avgPx":"1234.7"}}]}}}`
+	})
+
+	tolerance := 0.00001
+	tests := []struct {
+		size      float64
+		worstPx   float64
+		wantLimit string
+	}{
+		{size: 1, worstPx: 1234.73, wantLimit: "1234.8"},
+		{size: -1, worstPx: 1234.59, wantLimit: "1234.5"},
+	}
+	for _, tt := range tests {
+		res, err := api.MarketOrderFromBook("ETH", tt.size, &tolerance)
+		if err != nil {
+			t.Fatalf("MarketOrderFromBook(%v) error = %v", tt.size, err)
+		}
+		if limitPx != tt.wantLimit {
+			t.Errorf("MarketOrderFromBook(%v) limit = %v, want %v", tt.size, limitPx, tt.wantLimit)
+		}
+		if (tt.size > 0 && res.LimitPx < tt.worstPx) || (tt.size < 0 && res.LimitPx > tt.worstPx) {
+			t.Errorf("MarketOrderFromBook(%v) limit %v does not cover worst price %v", tt.size, res.LimitPx, tt.worstPx)
+		}
+	}
+}
+
+func TestExchangeAPI_ConvertToMultiSigUserSigners(t *testing.T) {
+	var body []byte
+	api := newTestExchangeAPI(t, func(endpoint string, request []byte) string {
+		body = request
+		return `{"status":"ok","response":{"type":"default"}}`
+	})
+	users := []string{"0x5e9ee1089755c3435139848e47e6635505d5a13a", "0x1d9470d4b963f552e6f671a81619d395877bf409"}
+	if _, err := api.ConvertToMultiSigUser(users, 2); err != nil {
+		t.Fatalf("ConvertToMultiSigUser() error = %v", err)
+	}
+	var request struct {
+		Action ConvertToMultiSigUserAction `json:"action"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if request.Action.Signers != goldenMultiSigSigners() {
+		t.Errorf("ConvertToMultiSigUser() signers = %v, want %v", request.Action.Signers, goldenMultiSigSigners())
+	}
+}
@@ -1,11 +1,14 @@
 package hyperliquid
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 func (api *ExchangeAPI) Sign(request *SignRequest) (byte, [32]byte, [32]byte, error) {
-	signer := NewSigner(api.keyManager)
+	signer := NewSignerWithLogger(api.keyManager, api.Logger)
 	v, r, s, err := signer.Sign(request)
 	if err != nil {
 		api.debug("Error SignInner: %s", err)
@@ -15,26 +18,21 @@ func (api *ExchangeAPI) Sign(request *SignRequest) (byte, [32]byte, [32]byte, er
 }
 
 func (api *ExchangeAPI) SignUserSignableAction(action any, payloadTypes []apitypes.Type, primaryType string) (byte, [32]byte, [32]byte, error) {
-	message, err := StructToMap(action)
+	signRequest, err := buildUserSignRequest(action, payloadTypes, primaryType, api.IsMainnet())
 	if err != nil {
 		return 0, [32]byte{}, [32]byte{}, err
 	}
-	// Remove unnecessary fields for signing
-	delete(message, "type")
-	delete(message, "signatureChainId")
-
-	signRequest := &SignRequest{
-		DomainName:  "HyperliquidSignTransaction",
-		PrimaryType: primaryType,
-		DType:       payloadTypes,
-		DTypeMsg:    message,
-		IsMainNet:   api.IsMainnet(),
-	}
 	return api.Sign(signRequest)
 }
 
 func (api *ExchangeAPI) SignL1Action(action any, timestamp uint64) (byte, [32]byte, [32]byte, error) {
-	srequest, err := api.BuildEIP712Message(action, timestamp)
+	return api.signL1ActionWithOptions(action, timestamp, "", nil)
+}
+
+// signL1ActionWithOptions signs an L1 action on behalf of a vault (empty for none)
+// and with an expiration time in milliseconds (nil for none).
+func (api *ExchangeAPI) signL1ActionWithOptions(action any, timestamp uint64, vaultAddress string, expiresAfter *uint64) (byte, [32]byte, [32]byte, error) {
+	srequest, err := api.buildL1SignRequest(action, timestamp, vaultAddress, expiresAfter)
 	if err != nil {
 		api.debug("Error building EIP712 message: %s", err)
 		return 0, [32]byte{}, [32]byte{}, err
@@ -43,48 +41,248 @@ func (api *ExchangeAPI) SignL1Action(action any, timestamp uint64) (byte, [32]by
 }
 
 func (api *ExchangeAPI) BuildEIP712Message(action any, timestamp uint64) (*SignRequest, error) {
-	hash, err := buildActionHash(action, "", timestamp)
+	return api.buildL1SignRequest(action, timestamp, "", nil)
+}
+
+func (api *ExchangeAPI) buildL1SignRequest(action any, timestamp uint64, vaultAddress string, expiresAfter *uint64) (*SignRequest, error) {
+	return buildL1SignRequest(action, timestamp, vaultAddress, expiresAfter, api.IsMainnet())
+}
+
+var withdrawSignTypes = []apitypes.Type{
+	{
+		Name: "hyperliquidChain",
+		Type: "string",
+	},
+	{
+		Name: "destination",
+		Type: "string",
+	},
+	{
+		Name: "amount",
+		Type: "string",
+	},
+	{
+		Name: "time",
+		Type: "uint64",
+	},
+}
+
+func (api *ExchangeAPI) SignWithdrawAction(action WithdrawAction) (byte, [32]byte, [32]byte, error) {
+	return api.SignUserSignableAction(action, withdrawSignTypes, "HyperliquidTransaction:Withdraw")
+}
+
+var convertToMultiSigUserSignTypes = []apitypes.Type{
+	{
+		Name: "hyperliquidChain",
+		Type: "string",
+	},
+	{
+		Name: "signers",
+		Type: "string",
+	},
+	{
+		Name: "nonce",
+		Type: "uint64",
+	},
+}
+
+func (api *ExchangeAPI) SignConvertToMultiSigUserAction(action ConvertToMultiSigUserAction) (byte, [32]byte, [32]byte, error) {
+	return api.SignUserSignableAction(action, convertToMultiSigUserSignTypes, "HyperliquidTransaction:ConvertToMultiSigUser")
+}
+
+// multiSigEnvelopeSignTypes are the types of the message signed by the outer signer of a multi-sig action.
+var multiSigEnvelopeSignTypes = []apitypes.Type{
+	{
+		Name: "hyperliquidChain",
+		Type: "string",
+	},
+	{
+		Name: "multiSigActionHash",
+		Type: "bytes32",
+	},
+	{
+		Name: "nonce",
+		Type: "uint64",
+	},
+}
+
+// SignMultiSigEnvelope signs a multi-sig action as its outer signer.
+// The action is hashed like an L1 action but without its type, with the expiry window of the API.
+func (api *ExchangeAPI) SignMultiSigEnvelope(action MultiSigAction, nonce uint64) (byte, [32]byte, [32]byte, error) {
+	hash, err := buildActionHash(action, "", nonce, api.l1ExpiresAfter(nonce))
 	if err != nil {
-		return nil, err
+		return 0, [32]byte{}, [32]byte{}, err
 	}
-	message := buildMessage(hash.Bytes(), api.IsMainnet())
-	srequest := &SignRequest{
-		DomainName:  "Exchange",
-		PrimaryType: "Agent",
-		DType: []apitypes.Type{
-			{
-				Name: "source",
-				Type: "string",
-			},
-			{
-				Name: "connectionId",
-				Type: "bytes32",
-			},
-		},
-		DTypeMsg:  message,
-		IsMainNet: api.IsMainnet(),
+	_, chainType := api.getChainParams()
+	envelope := MultiSigEnvelope{
+		HyperliquidChain:   chainType,
+		MultiSigActionHash: hash.Hex(),
+		Nonce:              nonce,
 	}
-	return srequest, nil
+	return api.SignUserSignableAction(envelope, multiSigEnvelopeSignTypes, "HyperliquidTransaction:SendMultiSig")
 }
 
-func (api *ExchangeAPI) SignWithdrawAction(action WithdrawAction) (byte, [32]byte, [32]byte, error) {
-	types := []apitypes.Type{
-		{
-			Name: "hyperliquidChain",
-			Type: "string",
-		},
-		{
-			Name: "destination",
-			Type: "string",
-		},
-		{
-			Name: "amount",
-			Type: "string",
-		},
-		{
-			Name: "time",
-			Type: "uint64",
-		},
+// addMultiSigTypes inserts the multi-sig fields after hyperliquidChain in the types of a user signed action.
+func addMultiSigTypes(payloadTypes []apitypes.Type) []apitypes.Type {
+	types := make([]apitypes.Type, 0, len(payloadTypes)+2)
+	for _, payloadType := range payloadTypes {
+		types = append(types, payloadType)
+		if payloadType.Name == "hyperliquidChain" {
+			types = append(types,
+				apitypes.Type{Name: "payloadMultiSigUser", Type: "address"},
+				apitypes.Type{Name: "outerSigner", Type: "address"},
+			)
+		}
+	}
+	return types
+}
+
+// SignMultiSigL1Action signs an L1 action as one of the authorized users of the multi-sig account multiSigUser.
+// outerSigner is the authorized user who submits the multi-sig action and nonce must be the nonce it is submitted with.
+// Every authorized user signs with its own ExchangeAPI, the signatures are then passed to MultiSig.
+// The expiry window of the API must be the one of the outer signer.
+func (api *ExchangeAPI) SignMultiSigL1Action(action any, multiSigUser string, outerSigner string, nonce uint64) (RsvSignature, error) {
+	envelope := []any{strings.ToLower(multiSigUser), strings.ToLower(outerSigner), action}
+	v, r, s, err := api.signL1ActionWithOptions(envelope, nonce, "", api.l1ExpiresAfter(nonce))
+	if err != nil {
+		return RsvSignature{}, err
+	}
+	return ToTypedSig(r, s, v), nil
+}
+
+// SignMultiSigUserSignedAction signs a user signed action (withdraw3, cDeposit...) as one of
+// the authorized users of the multi-sig account multiSigUser.
+// The nonce (or time) of the action must be the nonce the multi-sig action is submitted with.
+// See SignMultiSigL1Action.
+func (api *ExchangeAPI) SignMultiSigUserSignedAction(action any, multiSigUser string, outerSigner string) (RsvSignature, error) {
+	message, err := StructToMap(action)
+	if err != nil {
+		return RsvSignature{}, err
+	}
+	actionType, _ := message["type"].(string)
+	payloadTypes, primaryType, err := UserSignedActionTypes(actionType)
+	if err != nil {
+		return RsvSignature{}, err
+	}
+	message["payloadMultiSigUser"] = strings.ToLower(multiSigUser)
+	message["outerSigner"] = strings.ToLower(outerSigner)
+	v, r, s, err := api.SignUserSignableAction(message, addMultiSigTypes(payloadTypes), primaryType)
+	if err != nil {
+		return RsvSignature{}, err
+	}
+	return ToTypedSig(r, s, v), nil
+}
+
+var approveAgentSignTypes = []apitypes.Type{
+	{
+		Name: "hyperliquidChain",
+		Type: "string",
+	},
+	{
+		Name: "agentAddress",
+		Type: "address",
+	},
+	{
+		Name: "agentName",
+		Type: "string",
+	},
+	{
+		Name: "nonce",
+		Type: "uint64",
+	},
+}
+
+func (api *ExchangeAPI) SignApproveAgentAction(action ApproveAgentAction) (byte, [32]byte, [32]byte, error) {
+	return api.SignUserSignableAction(action, approveAgentSignTypes, "HyperliquidTransaction:ApproveAgent")
+}
+
+var cDepositSignTypes = []apitypes.Type{
+	{
+		Name: "hyperliquidChain",
+		Type: "string",
+	},
+	{
+		Name: "wei",
+		Type: "uint64",
+	},
+	{
+		Name: "nonce",
+		Type: "uint64",
+	},
+}
+
+func (api *ExchangeAPI) SignCDepositAction(action CDepositAction) (byte, [32]byte, [32]byte, error) {
+	return api.SignUserSignableAction(action, cDepositSignTypes, "HyperliquidTransaction:CDeposit")
+}
+
+var cWithdrawSignTypes = []apitypes.Type{
+	{
+		Name: "hyperliquidChain",
+		Type: "string",
+	},
+	{
+		Name: "wei",
+		Type: "uint64",
+	},
+	{
+		Name: "nonce",
+		Type: "uint64",
+	},
+}
+
+func (api *ExchangeAPI) SignCWithdrawAction(action CWithdrawAction) (byte, [32]byte, [32]byte, error) {
+	return api.SignUserSignableAction(action, cWithdrawSignTypes, "HyperliquidTransaction:CWithdraw")
+}
+
+var tokenDelegateSignTypes = []apitypes.Type{
+	{
+		Name: "hyperliquidChain",
+		Type: "string",
+	},
+	{
+		Name: "validator",
+		Type: "address",
+	},
+	{
+		Name: "wei",
+		Type: "uint64",
+	},
+	{
+		Name: "isUndelegate",
+		Type: "bool",
+	},
+	{
+		Name: "nonce",
+		Type: "uint64",
+	},
+}
+
+func (api *ExchangeAPI) SignTokenDelegateAction(action TokenDelegateAction) (byte, [32]byte, [32]byte, error) {
+	return api.SignUserSignableAction(action, tokenDelegateSignTypes, "HyperliquidTransaction:TokenDelegate")
+}
+
+// userSignedActionTypes maps the type of user signed actions to the EIP-712 payload types
+// and primary type they are signed with.
+var userSignedActionTypes = map[string]struct {
+	types       []apitypes.Type
+	primaryType string
+}{
+	"withdraw3":             {withdrawSignTypes, "HyperliquidTransaction:Withdraw"},
+	"convertToMultiSigUser": {convertToMultiSigUserSignTypes, "HyperliquidTransaction:ConvertToMultiSigUser"},
+	"approveAgent":          {approveAgentSignTypes, "HyperliquidTransaction:ApproveAgent"},
+	"cDeposit":              {cDepositSignTypes, "HyperliquidTransaction:CDeposit"},
+	"cWithdraw":             {cWithdrawSignTypes, "HyperliquidTransaction:CWithdraw"},
+	"tokenDelegate":         {tokenDelegateSignTypes, "HyperliquidTransaction:TokenDelegate"},
+}
+
+// UserSignedActionTypes returns the EIP-712 payload types and primary type of a user signed action type,
+// to be used with RecoverUserSignedActionSigner.
+//
+//	types, primaryType, err := UserSignedActionTypes("withdraw3")
+func UserSignedActionTypes(actionType string) ([]apitypes.Type, string, error) {
+	entry, ok := userSignedActionTypes[actionType]
+	if !ok {
+		return nil, "", APIError{Message: fmt.Sprintf("Unknown user signed action type: %s", actionType)}
 	}
-	return api.SignUserSignableAction(action, types, "HyperliquidTransaction:Withdraw")
+	return entry.types, entry.primaryType, nil
 }
@@ -0,0 +1,195 @@
+package hyperliquid
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testOtherPrivateKey = "abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"
+
+func TestExchangeAPI_MultiSigSignatures(t *testing.T) {
+	outer := GetOfflineExchangeAPI(t)
+	cosigner := NewOfflineExchangeAPI(true)
+	if err := cosigner.SetPrivateKey(testOtherPrivateKey); err != nil {
+		t.Fatalf("SetPrivateKey() error = %v", err)
+	}
+	multiSigUser := "0x1719884EB866CB12B2287399B15F7DB5E7D775EA"
+	outerSigner := outer.KeyManager().PublicAddressHex()
+	nonce := uint64(1700000000000)
+
+	// Inner L1 action signed by every authorized user
+	action := UpdateLeverageAction{Type: "updateLeverage", Asset: 1, IsCross: true, Leverage: 5}
+	envelope := []any{strings.ToLower(multiSigUser), strings.ToLower(outerSigner), action}
+	for _, api := range []*ExchangeAPI{outer, cosigner} {
+		sig, err := api.SignMultiSigL1Action(action, multiSigUser, outerSigner, nonce)
+		if err != nil {
+			t.Fatalf("SignMultiSigL1Action() error = %v", err)
+		}
+		signer, err := RecoverL1ActionSigner(envelope, nonce, "", nil, true, sig)
+		if err != nil {
+			t.Fatalf("RecoverL1ActionSigner() error = %v", err)
+		}
+		if signer != api.KeyManager().PublicAddress() {
+			t.Errorf("SignMultiSigL1Action() signer = %v, want %v", signer, api.KeyManager().PublicAddress())
+		}
+	}
+
+	// Inner user signed action carries the multi-sig fields
+	withdraw := WithdrawAction{Type: "withdraw3", SignatureChainID: "0xa4b1", HyperliquidChain: "Mainnet", Destination: outerSigner, Amount: "1", Time: nonce}
+	sig, err := cosigner.SignMultiSigUserSignedAction(withdraw, multiSigUser, outerSigner)
+	if err != nil {
+		t.Fatalf("SignMultiSigUserSignedAction() error = %v", err)
+	}
+	message, _ := StructToMap(withdraw)
+	message["payloadMultiSigUser"] = strings.ToLower(multiSigUser)
+	message["outerSigner"] = strings.ToLower(outerSigner)
+	types := addMultiSigTypes(withdrawSignTypes)
+	if len(types) != len(withdrawSignTypes)+2 || types[1].Name != "payloadMultiSigUser" || types[2].Name != "outerSigner" {
+		t.Errorf("addMultiSigTypes() = %+v", types)
+	}
+	signer, err := RecoverUserSignedActionSigner(message, types, "HyperliquidTransaction:Withdraw", true, sig)
+	if err != nil {
+		t.Fatalf("RecoverUserSignedActionSigner() error = %v", err)
+	}
+	if signer != cosigner.KeyManager().PublicAddress() {
+		t.Errorf("SignMultiSigUserSignedAction() signer = %v, want %v", signer, cosigner.KeyManager().PublicAddress())
+	}
+}
+
+func TestExchangeAPI_SignMultiSigEnvelope(t *testing.T) {
+	outer := GetOfflineExchangeAPI(t)
+	nonce := uint64(1700000000000)
+	action := MultiSigAction{
+		Type:             "multiSig",
+		SignatureChainID: "0xa4b1",
+		Signatures:       []RsvSignature{{R: "0x01", S: "0x02", V: 27}},
+		Payload: MultiSigPayload{
+			MultiSigUser: "0x1719884eb866cb12b2287399b15f7db5e7d775ea",
+			OuterSigner:  strings.ToLower(outer.KeyManager().PublicAddressHex()),
+			Action:       SetReferrerAction{Type: "setReferrer", Code: "TESTCODE"},
+		},
+	}
+	// The type is not hashed
+	withType, _ := buildActionHash(action, "", nonce, nil)
+	untyped := action
+	untyped.Type = ""
+	withoutType, _ := buildActionHash(untyped, "", nonce, nil)
+	if withType != withoutType {
+		t.Errorf("buildActionHash() hashes the multiSig type")
+	}
+
+	v, r, s, err := outer.SignMultiSigEnvelope(action, nonce)
+	if err != nil {
+		t.Fatalf("SignMultiSigEnvelope() error = %v", err)
+	}
+	envelope := MultiSigEnvelope{HyperliquidChain: "Mainnet", MultiSigActionHash: withType.Hex(), Nonce: nonce}
+	signer, err := RecoverUserSignedActionSigner(envelope, multiSigEnvelopeSignTypes, "HyperliquidTransaction:SendMultiSig", true, ToTypedSig(r, s, v))
+	if err != nil {
+		t.Fatalf("RecoverUserSignedActionSigner() error = %v", err)
+	}
+	if signer != outer.KeyManager().PublicAddress() {
+		t.Errorf("SignMultiSigEnvelope() signer = %v, want %v", signer, outer.KeyManager().PublicAddress())
+	}
+}
+
+func TestExchangeAPI_ExpiresAfter(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	nonce := uint64(1700000000000)
+	action := UpdateLeverageAction{Type: "updateLeverage", Asset: 1, IsCross: true, Leverage: 5}
+
+	request, err := api.signL1Request(action, nonce, "")
+	if err != nil {
+		t.Fatalf("signL1Request() error = %v", err)
+	}
+	if request.ExpiresAfter != nil {
+		t.Errorf("signL1Request() ExpiresAfter = %v without window", *request.ExpiresAfter)
+	}
+
+	bounded := api.WithExpiresAfter(5 * time.Second)
+	if api.expiresAfter != 0 {
+		t.Errorf("WithExpiresAfter() changed the window of the API to %v", api.expiresAfter)
+	}
+	request, err = bounded.signL1Request(action, nonce, "")
+	if err != nil {
+		t.Fatalf("signL1Request() error = %v", err)
+	}
+	if request.ExpiresAfter == nil || *request.ExpiresAfter != nonce+5000 {
+		t.Fatalf("signL1Request() ExpiresAfter = %v, want %d", request.ExpiresAfter, nonce+5000)
+	}
+	// The expiry time is part of the signed hash
+	signer, err := RecoverL1ActionSigner(action, nonce, "", request.ExpiresAfter, true, request.Signature)
+	if err != nil {
+		t.Fatalf("RecoverL1ActionSigner() error = %v", err)
+	}
+	if signer != api.KeyManager().PublicAddress() {
+		t.Errorf("signL1Request() signer = %v, want %v", signer, api.KeyManager().PublicAddress())
+	}
+	signer, err = RecoverL1ActionSigner(action, nonce, "", nil, true, request.Signature)
+	if err == nil && signer == api.KeyManager().PublicAddress() {
+		t.Errorf("signL1Request() signature does not cover the expiry time")
+	}
+}
+
+// fixedNonceManager hands out the same nonce to every signer.
+type fixedNonceManager uint64
+
+func (n fixedNonceManager) Next(signer common.Address) (uint64, error) {
+	return uint64(n), nil
+}
+
+func TestExchangeAPI_WithExpiresAfterShares(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	api.SetAccountAddress(api.KeyManager().PublicAddressHex())
+	bounded := api.WithExpiresAfter(time.Minute)
+
+	// The signer, the nonces, the info API and the metadata are shared
+	if bounded.KeyManager() != api.KeyManager() || bounded.infoAPI != api.infoAPI {
+		t.Errorf("WithExpiresAfter() does not share the key manager and the info API")
+	}
+	first, _ := api.nextNonce()
+	second, _ := bounded.nextNonce()
+	third, _ := api.nextNonce()
+	if !(first < second && second < third) {
+		t.Errorf("nonces = %d, %d, %d, want increasing across the API and its copy", first, second, third)
+	}
+	api.meta["NEW"] = AssetInfo{AssetId: 1000}
+	if _, ok := bounded.meta["NEW"]; !ok {
+		t.Errorf("WithExpiresAfter() does not share the asset metadata")
+	}
+
+	// The settings are copied
+	api.SetVaultAddress("0x1719884eb866cb12b2287399b15f7db5e7d775ea")
+	api.SetPreTradeCheck(true)
+	bounded.SetAccountAddress("")
+	if bounded.VaultAddress() != "" || bounded.preTrade || api.AccountAddress() == "" {
+		t.Errorf("WithExpiresAfter() settings are shared, vault %q, pre-trade %v, account %q",
+			bounded.VaultAddress(), bounded.preTrade, api.AccountAddress())
+	}
+}
+
+func TestExchangeAPI_BuildBulkOrdersEIP712ExpiresAfter(t *testing.T) {
+	nonce := uint64(1700000000000)
+	api := GetOfflineExchangeAPI(t).WithExpiresAfter(time.Minute)
+	api.nonceManager = fixedNonceManager(nonce)
+	api.meta["ETH"] = AssetInfo{AssetId: 1}
+	order := OrderRequest{Coin: "ETH", IsBuy: true, Sz: 100, LimitPx: 100, OrderType: OrderType{Limit: &LimitOrderType{Tif: TifGtc}}}
+
+	typedData, err := api.BuildOrderEIP712(order, GroupingNa)
+	if err != nil {
+		t.Fatalf("BuildOrderEIP712() error = %v", err)
+	}
+	// The message is the one BulkOrders signs, with the expiry window of the API
+	expiresAfter := nonce + 60000
+	action := OrderWiresToOrderAction([]OrderWire{OrderRequestToWire(order, api.meta, false)}, GroupingNa)
+	expected, err := buildL1SignRequest(action, nonce, "", &expiresAfter, true)
+	if err != nil {
+		t.Fatalf("buildL1SignRequest() error = %v", err)
+	}
+	if !reflect.DeepEqual(typedData.Message, expected.DTypeMsg) {
+		t.Errorf("BuildOrderEIP712() message = %v, want %v", typedData.Message, expected.DTypeMsg)
+	}
+}
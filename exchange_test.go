@@ -386,3 +386,33 @@ func TestExchageAPI_TestMarketOrderSpot(t *testing.T) {
 		t.Errorf("res.Response.Data.Statuses[0].Filled.AvgPx = %v", avgPrice)
 	}
 }
+
+func TestExchangeAPI_TestPreTradeCheck(t *testing.T) {
+	exchangeAPI := GetExchangeAPI(t)
+	exchangeAPI.SetPreTradeCheck(true)
+	_, err := exchangeAPI.MarketOrder("ETH", 1e9, nil)
+	if err == nil {
+		t.Fatalf("MarketOrder() error = nil, want oversized order error")
+	}
+	t.Logf("MarketOrder() error = %v", err)
+}
+
+func TestExchangeAPI_TestMarketOrderFromBook(t *testing.T) {
+	exchangeAPI := GetExchangeAPI(t)
+	size := 0.01
+	res, err := exchangeAPI.MarketOrderFromBook("ETH", size, nil)
+	if err != nil {
+		t.Fatalf("MarketOrderFromBook() error = %v", err)
+	}
+	t.Logf("MarketOrderFromBook() = %+v", res)
+	if res.FilledSz != size {
+		t.Errorf("res.FilledSz = %v, want %v", res.FilledSz, size)
+	}
+	if res.RealizedAvgPx > res.LimitPx {
+		t.Errorf("res.RealizedAvgPx = %v, want <= limit %v", res.RealizedAvgPx, res.LimitPx)
+	}
+	time.Sleep(2 * time.Second) // wait to execute order
+	if _, err := exchangeAPI.ClosePosition("ETH"); err != nil {
+		t.Errorf("ClosePosition() error = %v", err)
+	}
+}
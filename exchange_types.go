@@ -6,9 +6,9 @@ import (
 )
 
 type RsvSignature struct {
-	R string `json:"r"`
-	S string `json:"s"`
-	V byte   `json:"v"`
+	R string `json:"r" msgpack:"r"`
+	S string `json:"s" msgpack:"s"`
+	V byte   `json:"v" msgpack:"v"`
 }
 
 // Base request for /exchange endpoint
@@ -17,6 +17,29 @@ type ExchangeRequest struct {
 	Nonce        uint64       `json:"nonce"`
 	Signature    RsvSignature `json:"signature"`
 	VaultAddress *string      `json:"vaultAddress,omitempty" msgpack:",omitempty"`
+	ExpiresAfter *uint64      `json:"expiresAfter,omitempty" msgpack:",omitempty"`
+}
+
+// SignedAction is an action signed ahead of its submission.
+// It can be serialized with encoding/json, moved to another machine and submitted with SubmitSigned.
+// Action holds the exact JSON of the signed action, it must not be modified.
+type SignedAction struct {
+	Action       json.RawMessage `json:"action"`
+	Nonce        uint64          `json:"nonce"`
+	Signature    RsvSignature    `json:"signature"`
+	VaultAddress *string         `json:"vaultAddress,omitempty"`
+	ExpiresAfter *uint64         `json:"expiresAfter,omitempty"`
+}
+
+// Request returns the /exchange request submitting the signed action.
+func (signed *SignedAction) Request() *ExchangeRequest {
+	return &ExchangeRequest{
+		Action:       signed.Action,
+		Nonce:        signed.Nonce,
+		Signature:    signed.Signature,
+		VaultAddress: signed.VaultAddress,
+		ExpiresAfter: signed.ExpiresAfter,
+	}
 }
 
 type AssetInfo struct {
@@ -94,8 +117,8 @@ type ModifyOrderWire struct {
 	Order   OrderWire `msgpack:"order" json:"order"`
 }
 type ModifyOrderAction struct {
-	Type     string               `msgpack:"type" json:"type"`
-	Modifies []ModifyOrderRequest `msgpack:"modifies" json:"modifies"`
+	Type     string            `msgpack:"type" json:"type"`
+	Modifies []ModifyOrderWire `msgpack:"modifies" json:"modifies"`
 }
 
 type ModifyOrderRequest struct {
@@ -193,6 +216,14 @@ type RestingStatus struct {
 	Cloid   string `json:"cloid,omitempty"`
 }
 
+// OrderReconciliation is the resolved outcome of an order placed with a cloid.
+// Status is nil when the exchange has no record of the order.
+type OrderReconciliation struct {
+	Cloid  string
+	Placed bool
+	Status *OrderStatusInfo
+}
+
 type CloseRequest struct {
 	Coin     string
 	Px       float64
@@ -208,6 +239,20 @@ type FilledStatus struct {
 	Cloid   string  `json:"cloid,omitempty"`
 }
 
+// MarketOrderResult is the outcome of a market order priced from the L2 book.
+// Slippages are in basis points of the mid price, positive when the price is worse than the mid.
+// The realized values are 0 if the order did not fill.
+type MarketOrderResult struct {
+	Response            *OrderResponse
+	MidPx               float64
+	LimitPx             float64
+	ExpectedAvgPx       float64
+	ExpectedSlippageBps float64
+	RealizedAvgPx       float64
+	RealizedSlippageBps float64
+	FilledSz            float64
+}
+
 type Liquidation struct {
 	User      string `json:"liquidatedUser"`
 	MarkPrice string `json:"markPx"`
@@ -262,14 +307,145 @@ type Deposit struct {
 
 type WithdrawAction struct {
 	Type             string `msgpack:"type" json:"type"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
 	Destination      string `msgpack:"destination" json:"destination"`
 	Amount           string `msgpack:"amount" json:"amount"`
 	Time             uint64 `msgpack:"time" json:"time"`
+}
+
+// Approve an agent (API wallet) to sign L1 actions for the account
+type ApproveAgentAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
 	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	AgentAddress     string `msgpack:"agentAddress" json:"agentAddress"`
+	AgentName        string `msgpack:"agentName" json:"agentName"`
+	Nonce            uint64 `msgpack:"nonce" json:"nonce"`
+}
+
+// Convert the account to a multi-sig user
+// Signers is the JSON of MultiSigSigners.
+type ConvertToMultiSigUserAction struct {
+	Type             string `msgpack:"type" json:"type"`
 	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	Signers          string `msgpack:"signers" json:"signers"`
+	Nonce            uint64 `msgpack:"nonce" json:"nonce"`
+}
+
+// Users allowed to sign for a multi-sig account and the number of signatures required
+type MultiSigSigners struct {
+	AuthorizedUsers []string `json:"authorizedUsers"`
+	Threshold       int      `json:"threshold"`
+}
+
+// Action sent by a multi-sig account, signed by Signatures of its authorized users
+// and wrapped by the outer signer who submits it.
+// The type is not part of the hashed action, so it is skipped by msgpack.
+type MultiSigAction struct {
+	Type             string          `msgpack:"-" json:"type"`
+	SignatureChainID string          `msgpack:"signatureChainId" json:"signatureChainId"`
+	Signatures       []RsvSignature  `msgpack:"signatures" json:"signatures"`
+	Payload          MultiSigPayload `msgpack:"payload" json:"payload"`
+}
+
+// Inner action of a multi-sig action
+// MultiSigUser and OuterSigner are lowercase addresses.
+type MultiSigPayload struct {
+	MultiSigUser string `msgpack:"multiSigUser" json:"multiSigUser"`
+	OuterSigner  string `msgpack:"outerSigner" json:"outerSigner"`
+	Action       any    `msgpack:"action" json:"action"`
+}
+
+// Message signed by the outer signer of a multi-sig action
+type MultiSigEnvelope struct {
+	HyperliquidChain   string `json:"hyperliquidChain"`
+	MultiSigActionHash string `json:"multiSigActionHash"`
+	Nonce              uint64 `json:"nonce"`
 }
 
 type WithdrawResponse struct {
 	Status string `json:"status"`
 	Nonce  int64
 }
+
+type CreateSubAccountAction struct {
+	Type string `msgpack:"type" json:"type"`
+	Name string `msgpack:"name" json:"name"`
+}
+
+// Response of createSubAccount, Data is the address of the new sub-account
+type CreateSubAccountResponse struct {
+	Status   string `json:"status"`
+	Response struct {
+		Type string `json:"type"`
+		Data string `json:"data"`
+	} `json:"response"`
+}
+
+// Usd is the USDC amount in micro units (1 USDC = 1000000)
+type SubAccountTransferAction struct {
+	Type           string `msgpack:"type" json:"type"`
+	SubAccountUser string `msgpack:"subAccountUser" json:"subAccountUser"`
+	IsDeposit      bool   `msgpack:"isDeposit" json:"isDeposit"`
+	Usd            int    `msgpack:"usd" json:"usd"`
+}
+
+// Token has the format "<name>:<tokenId>", e.g. "PURR:0xc4bf3f870c0e9465323c0b6ed28096c2"
+type SubAccountSpotTransferAction struct {
+	Type           string `msgpack:"type" json:"type"`
+	SubAccountUser string `msgpack:"subAccountUser" json:"subAccountUser"`
+	IsDeposit      bool   `msgpack:"isDeposit" json:"isDeposit"`
+	Token          string `msgpack:"token" json:"token"`
+	Amount         string `msgpack:"amount" json:"amount"`
+}
+
+// Usd is the USDC amount in micro units (1 USDC = 1000000)
+type VaultTransferAction struct {
+	Type         string `msgpack:"type" json:"type"`
+	VaultAddress string `msgpack:"vaultAddress" json:"vaultAddress"`
+	IsDeposit    bool   `msgpack:"isDeposit" json:"isDeposit"`
+	Usd          int    `msgpack:"usd" json:"usd"`
+}
+
+// Transfer HYPE from the spot balance to the staking balance
+type CDepositAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	Wei              uint64 `msgpack:"wei" json:"wei"`
+	Nonce            uint64 `msgpack:"nonce" json:"nonce"`
+}
+
+// Transfer HYPE from the staking balance to the spot balance
+type CWithdrawAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	Wei              uint64 `msgpack:"wei" json:"wei"`
+	Nonce            uint64 `msgpack:"nonce" json:"nonce"`
+}
+
+// Delegate or undelegate HYPE from the staking balance to a validator
+type TokenDelegateAction struct {
+	Type             string `msgpack:"type" json:"type"`
+	SignatureChainID string `msgpack:"signatureChainId" json:"signatureChainId"`
+	HyperliquidChain string `msgpack:"hyperliquidChain" json:"hyperliquidChain"`
+	Validator        string `msgpack:"validator" json:"validator"`
+	Wei              uint64 `msgpack:"wei" json:"wei"`
+	IsUndelegate     bool   `msgpack:"isUndelegate" json:"isUndelegate"`
+	Nonce            uint64 `msgpack:"nonce" json:"nonce"`
+}
+
+// Attach the account to a referral code
+type SetReferrerAction struct {
+	Type string `msgpack:"type" json:"type"`
+	Code string `msgpack:"code" json:"code"`
+}
+
+// Create the referral code of the account
+type RegisterReferrerAction struct {
+	Type string `msgpack:"type" json:"type"`
+	Code string `msgpack:"code" json:"code"`
+}
@@ -0,0 +1,119 @@
+package hyperliquid
+
+import (
+	"time"
+)
+
+// Funding constants
+const FUNDING_INTERVAL_HOURS = 1 // Hyperliquid pays funding every hour
+const HOURS_PER_YEAR = 24 * 365
+
+// FundingProjection is the projected next funding payment of a position.
+// Payment is positive when the position receives funding and negative when it pays.
+type FundingProjection struct {
+	Coin            string
+	Szi             float64
+	MarkPx          float64
+	FundingRate     float64
+	Payment         float64
+	NextFundingTime int64
+}
+
+// AnnualizeFundingRate converts a funding rate paid every intervalHours into a yearly rate.
+// The rate is not compounded.
+//
+//	AnnualizeFundingRate(0.0000125, FUNDING_INTERVAL_HOURS) // 0.1095 (10.95% per year)
+func AnnualizeFundingRate(rate float64, intervalHours float64) float64 {
+	if intervalHours <= 0 {
+		intervalHours = FUNDING_INTERVAL_HOURS
+	}
+	return rate * HOURS_PER_YEAR / intervalHours
+}
+
+// AverageFundingRate returns the mean funding rate of the given history.
+// Returns 0 for an empty history.
+func AverageFundingRate(rates []HistoricalFundingRate) float64 {
+	if len(rates) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, rate := range rates {
+		sum += rate.FundingRate
+	}
+	return sum / float64(len(rates))
+}
+
+// RollingAverageFundingRates returns the rolling average of the funding rates over window samples.
+// The i-th value is the average of rates[i-window+1:i+1].
+// The first window-1 values average the samples available so far.
+func RollingAverageFundingRates(rates []HistoricalFundingRate, window int) []float64 {
+	if window <= 0 {
+		window = 1
+	}
+	result := make([]float64, len(rates))
+	sum := 0.0
+	for i, rate := range rates {
+		sum += rate.FundingRate
+		if i >= window {
+			sum -= rates[i-window].FundingRate
+		}
+		result[i] = sum / float64(min(i+1, window))
+	}
+	return result
+}
+
+// ProjectFundingPayment returns the funding payment of a position of size szi.
+// Longs pay shorts when the funding rate is positive, so the result is negative
+// for a long position and a positive rate.
+func ProjectFundingPayment(szi float64, markPx float64, fundingRate float64) float64 {
+	return -szi * markPx * fundingRate
+}
+
+// NextFundingTime returns the time of the next funding payment in milliseconds.
+// Funding is paid at the start of every hour.
+func NextFundingTime(now time.Time) int64 {
+	return now.Truncate(time.Hour).Add(time.Hour).UnixMilli()
+}
+
+// Helper function to project the next funding payment of every open position of a given address
+// The projection uses the current funding rate and mark price from GetMetaAndAssetCtxs.
+func (api *InfoAPI) ProjectNextFundingPayments(address string) (*[]FundingProjection, error) {
+	state, err := api.GetUserState(address)
+	if err != nil {
+		return nil, err
+	}
+	assets, err := api.GetMetaAndAssetCtxs()
+	if err != nil {
+		return nil, err
+	}
+	contexts := make(map[string]Context, len(*assets))
+	for _, asset := range *assets {
+		contexts[asset.Name] = asset.Context
+	}
+	nextFundingTime := NextFundingTime(time.Now())
+	projections := make([]FundingProjection, 0, len(state.AssetPositions))
+	for _, assetPosition := range state.AssetPositions {
+		position := assetPosition.Position
+		ctx, ok := contexts[position.Coin]
+		if !ok {
+			api.debug("No asset context for %s", position.Coin)
+			continue
+		}
+		projections = append(projections, FundingProjection{
+			Coin:            position.Coin,
+			Szi:             position.Szi,
+			MarkPx:          ctx.MarkPx,
+			FundingRate:     ctx.Funding,
+			Payment:         ProjectFundingPayment(position.Szi, ctx.MarkPx, ctx.Funding),
+			NextFundingTime: nextFundingTime,
+		})
+	}
+	return &projections, nil
+}
+
+// Helper function to project the next funding payment of every open position of the account address
+// The same as ProjectNextFundingPayments but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) ProjectAccountNextFundingPayments() (*[]FundingProjection, error) {
+	return api.ProjectNextFundingPayments(api.AccountAddress())
+}
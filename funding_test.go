@@ -0,0 +1,111 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func almostEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-12
+}
+
+func TestFunding_AnnualizeFundingRate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rate          float64
+		intervalHours float64
+		expected      float64
+	}{
+		{
+			name:          "Hyperliquid hourly",
+			rate:          0.0000125,
+			intervalHours: FUNDING_INTERVAL_HOURS,
+			expected:      0.1095,
+		},
+		{
+			name:          "8 hours venue",
+			rate:          0.0001,
+			intervalHours: 8,
+			expected:      0.1095,
+		},
+		{
+			name:          "Negative rate",
+			rate:          -0.00001,
+			intervalHours: 1,
+			expected:      -0.0876,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := AnnualizeFundingRate(tc.rate, tc.intervalHours)
+			if !almostEqual(res, tc.expected) {
+				t.Errorf("AnnualizeFundingRate() = %v, want %v", res, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFunding_RollingAverageFundingRates(t *testing.T) {
+	rates := []HistoricalFundingRate{
+		{FundingRate: 1},
+		{FundingRate: 2},
+		{FundingRate: 3},
+		{FundingRate: 4},
+	}
+	expected := []float64{1, 1.5, 2.5, 3.5}
+	res := RollingAverageFundingRates(rates, 2)
+	for i := range expected {
+		if !almostEqual(res[i], expected[i]) {
+			t.Errorf("RollingAverageFundingRates()[%d] = %v, want %v", i, res[i], expected[i])
+		}
+	}
+	if avg := AverageFundingRate(rates); !almostEqual(avg, 2.5) {
+		t.Errorf("AverageFundingRate() = %v, want %v", avg, 2.5)
+	}
+}
+
+func TestFunding_ProjectFundingPayment(t *testing.T) {
+	// A long position pays positive funding
+	if res := ProjectFundingPayment(2, 100, 0.0001); !almostEqual(res, -0.02) {
+		t.Errorf("ProjectFundingPayment() = %v, want %v", res, -0.02)
+	}
+	// A short position receives positive funding
+	if res := ProjectFundingPayment(-2, 100, 0.0001); !almostEqual(res, 0.02) {
+		t.Errorf("ProjectFundingPayment() = %v, want %v", res, 0.02)
+	}
+}
+
+func TestFunding_NextFundingTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 15, 30, 0, time.UTC)
+	expected := time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC).UnixMilli()
+	if res := NextFundingTime(now); res != expected {
+		t.Errorf("NextFundingTime() = %v, want %v", res, expected)
+	}
+}
+
+func TestFunding_PredictedFundingUnmarshal(t *testing.T) {
+	data := `[["AVAX", [
+		["BinPerp", {"fundingRate": "0.0001", "nextFundingTime": 1733961600000}],
+		["HlPerp", {"fundingRate": "0.0000125", "nextFundingTime": 1733958000000}],
+		["BybitPerp", null]
+	]]]`
+	var res []PredictedFunding
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(res) != 1 || res[0].Coin != "AVAX" {
+		t.Fatalf("PredictedFunding = %+v, want AVAX", res)
+	}
+	if len(res[0].Venues) != 2 {
+		t.Errorf("Venues len = %v, want %v", len(res[0].Venues), 2)
+	}
+	hl, ok := res[0].Venue(VenueHyperliquid)
+	if !ok || hl.FundingRate != 0.0000125 || hl.NextFundingTime != 1733958000000 {
+		t.Errorf("Venue(%s) = %+v, %v", VenueHyperliquid, hl, ok)
+	}
+	if _, ok := res[0].Venue(VenueBybit); ok {
+		t.Errorf("Venue(%s) found, want missing", VenueBybit)
+	}
+}
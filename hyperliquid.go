@@ -12,11 +12,13 @@ type Hyperliquid struct {
 
 // HyperliquidClientConfig is a configuration struct for Hyperliquid API.
 // PrivateKey can be empty if you only need to use the public endpoints.
+// KeyManager is used instead of PrivateKey when set, see the NewPKeyManagerFrom* constructors.
 // AccountAddress is the default account address for the API that can be changed with SetAccountAddress().
 // AccountAddress may be different from the address build from the private key due to Hyperliquid's account system.
 type HyperliquidClientConfig struct {
 	IsMainnet      bool
 	PrivateKey     string
+	KeyManager     *PKeyManager
 	AccountAddress string
 }
 
@@ -32,7 +34,11 @@ func NewHyperliquid(config *HyperliquidClientConfig, options ...ClientOption) *H
 		defaultConfig = config
 	}
 	exchangeAPI := NewExchangeAPI(defaultConfig.IsMainnet, options...)
-	exchangeAPI.SetPrivateKey(defaultConfig.PrivateKey)
+	if defaultConfig.KeyManager != nil {
+		exchangeAPI.SetKeyManager(defaultConfig.KeyManager)
+	} else {
+		exchangeAPI.SetPrivateKey(defaultConfig.PrivateKey)
+	}
 	exchangeAPI.SetAccountAddress(defaultConfig.AccountAddress)
 	infoAPI := NewInfoAPI(defaultConfig.IsMainnet, options...)
 	infoAPI.SetAccountAddress(defaultConfig.AccountAddress)
@@ -55,6 +61,10 @@ func (h *Hyperliquid) SetPrivateKey(privateKey string) error {
 	return nil
 }
 
+func (h *Hyperliquid) SetKeyManager(keyManager *PKeyManager) {
+	h.ExchangeAPI.SetKeyManager(keyManager)
+}
+
 func (h *Hyperliquid) SetAccountAddress(accountAddress string) {
 	h.ExchangeAPI.SetAccountAddress(accountAddress)
 	h.InfoAPI.SetAccountAddress(accountAddress)
@@ -0,0 +1,185 @@
+package hyperliquid
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+)
+
+// Maximum number of rows returned by a single request of the time ranged info endpoints.
+const (
+	FUNDING_PAGE_LIMIT         = 500
+	NON_FUNDING_PAGE_LIMIT     = 500
+	FUNDING_HISTORY_PAGE_LIMIT = 500
+	CANDLE_PAGE_LIMIT          = 5000
+	FILLS_BY_TIME_PAGE_LIMIT   = 2000
+)
+
+// paginate walks the [startTime, endTime] window page by page.
+// Each page is requested from the last timestamp seen so far, so rows sharing the
+// boundary timestamp are returned twice by the API and are skipped using keyOf.
+// The walk stops when a page has less than pageLimit rows or the end of the window is reached.
+// A full page with only rows already seen means that pageLimit rows or more share the boundary
+// timestamp; the rows past that page cannot be requested so an error is yielded instead.
+// If endTime is 0 the window ends at the current time.
+func paginate[T any](
+	startTime int64,
+	endTime int64,
+	pageLimit int,
+	fetch func(startTime int64, endTime int64) ([]T, error),
+	timeOf func(T) int64,
+	keyOf func(T) string,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if endTime == 0 {
+			endTime = time.Now().UnixMilli()
+		}
+		boundary := int64(-1)
+		seen := make(map[string]struct{}) // keys of rows at the boundary timestamp
+		for startTime <= endTime {
+			page, err := fetch(startTime, endTime)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			last := boundary
+			emitted := 0
+			for _, row := range page {
+				ts := timeOf(row)
+				if ts == boundary {
+					key := keyOf(row)
+					if _, ok := seen[key]; ok {
+						continue
+					}
+					seen[key] = struct{}{}
+				}
+				if ts > last {
+					last = ts
+				}
+				emitted++
+				if !yield(row, nil) {
+					return
+				}
+			}
+			if len(page) < pageLimit || last >= endTime {
+				return
+			}
+			if emitted == 0 {
+				var zero T
+				yield(zero, APIError{Message: fmt.Sprintf("%d rows or more at timestamp %d, cannot paginate past them", pageLimit, boundary)})
+				return
+			}
+			if last != boundary {
+				boundary = last
+				seen = make(map[string]struct{})
+			}
+			for _, row := range page {
+				if timeOf(row) == boundary {
+					seen[keyOf(row)] = struct{}{}
+				}
+			}
+			startTime = boundary
+		}
+	}
+}
+
+// Iterate over a user's funding history in the given time range
+// Pages of FUNDING_PAGE_LIMIT rows are requested until the whole range is covered.
+// endTime can be 0 to iterate up to the current time.
+//
+// Example:
+//
+//	for update, err := range api.IterFundingUpdates(address, startTime, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (api *InfoAPI) IterFundingUpdates(address string, startTime int64, endTime int64) iter.Seq2[FundingUpdate, error] {
+	return paginate(startTime, endTime, FUNDING_PAGE_LIMIT,
+		func(startTime int64, endTime int64) ([]FundingUpdate, error) {
+			res, err := api.GetFundingUpdates(address, startTime, endTime)
+			if err != nil {
+				return nil, err
+			}
+			return *res, nil
+		},
+		func(update FundingUpdate) int64 { return update.Time },
+		func(update FundingUpdate) string { return update.Delta.Asset },
+	)
+}
+
+// Iterate over a user's non-funding ledger updates in the given time range
+// Pages of NON_FUNDING_PAGE_LIMIT rows are requested until the whole range is covered.
+// endTime can be 0 to iterate up to the current time.
+func (api *InfoAPI) IterNonFundingUpdates(address string, startTime int64, endTime int64) iter.Seq2[NonFundingUpdate, error] {
+	return paginate(startTime, endTime, NON_FUNDING_PAGE_LIMIT,
+		func(startTime int64, endTime int64) ([]NonFundingUpdate, error) {
+			res, err := api.GetNonFundingUpdates(address, startTime, endTime)
+			if err != nil {
+				return nil, err
+			}
+			return *res, nil
+		},
+		func(update NonFundingUpdate) int64 { return update.Time },
+		func(update NonFundingUpdate) string {
+			delta := update.Delta
+			return fmt.Sprintf("%s|%s|%s|%v|%v|%d", update.Hash, delta.Type, delta.Token, delta.Usdc, delta.Amount, delta.Nonce)
+		},
+	)
+}
+
+// Iterate over historical funding rates of a coin in the given time range
+// Pages of FUNDING_HISTORY_PAGE_LIMIT rows are requested until the whole range is covered.
+// endTime can be 0 to iterate up to the current time.
+func (api *InfoAPI) IterHistoricalFundingRates(coin string, startTime int64, endTime int64) iter.Seq2[HistoricalFundingRate, error] {
+	return paginate(startTime, endTime, FUNDING_HISTORY_PAGE_LIMIT,
+		func(startTime int64, endTime int64) ([]HistoricalFundingRate, error) {
+			res, err := api.GetHistoricalFundingRates(coin, startTime, endTime)
+			if err != nil {
+				return nil, err
+			}
+			return *res, nil
+		},
+		func(rate HistoricalFundingRate) int64 { return rate.Time },
+		func(rate HistoricalFundingRate) string { return rate.Coin },
+	)
+}
+
+// Iterate over candles of a coin in the given time range
+// Pages of CANDLE_PAGE_LIMIT candles are requested until the whole range is covered.
+// Candles are paginated by their open time (the "t" field).
+// endTime can be 0 to iterate up to the current time.
+func (api *InfoAPI) IterCandles(coin string, interval string, startTime int64, endTime int64) iter.Seq2[CandleSnapshot, error] {
+	return paginate(startTime, endTime, CANDLE_PAGE_LIMIT,
+		func(startTime int64, endTime int64) ([]CandleSnapshot, error) {
+			res, err := api.GetCandleSnapshot(coin, interval, startTime, endTime)
+			if err != nil {
+				return nil, err
+			}
+			return *res, nil
+		},
+		func(candle CandleSnapshot) int64 { return candle.CloseTime },
+		func(candle CandleSnapshot) string { return candle.Symbol + candle.Interval },
+	)
+}
+
+// Iterate over a user's fills in the given time range
+// Pages of FILLS_BY_TIME_PAGE_LIMIT fills are requested until the whole range is covered.
+// Note that only the 10000 most recent fills of a user are available.
+// endTime can be 0 to iterate up to the current time.
+func (api *InfoAPI) IterUserFillsByTime(address string, startTime int64, endTime int64, aggregateByTime bool) iter.Seq2[OrderFill, error] {
+	return paginate(startTime, endTime, FILLS_BY_TIME_PAGE_LIMIT,
+		func(startTime int64, endTime int64) ([]OrderFill, error) {
+			res, err := api.GetUserFillsByTime(address, startTime, endTime, aggregateByTime)
+			if err != nil {
+				return nil, err
+			}
+			return *res, nil
+		},
+		func(fill OrderFill) int64 { return fill.Time },
+		func(fill OrderFill) string { return strconv.FormatInt(fill.Tid, 10) + "|" + strconv.Itoa(fill.Oid) },
+	)
+}
@@ -0,0 +1,147 @@
+package hyperliquid
+
+import (
+	"errors"
+	"testing"
+)
+
+type paginationRow struct {
+	Time int64
+	Key  string
+}
+
+// fakePages serves rows sorted by time, at most limit rows per request.
+func fakePages(rows []paginationRow, limit int, calls *int) func(int64, int64) ([]paginationRow, error) {
+	return func(startTime int64, endTime int64) ([]paginationRow, error) {
+		*calls++
+		var page []paginationRow
+		for _, row := range rows {
+			if row.Time < startTime || row.Time > endTime {
+				continue
+			}
+			if len(page) == limit {
+				break
+			}
+			page = append(page, row)
+		}
+		return page, nil
+	}
+}
+
+func collectRows(t *testing.T, rows []paginationRow, limit int, startTime int64, endTime int64) ([]paginationRow, int) {
+	calls := 0
+	var result []paginationRow
+	seq := paginate(startTime, endTime, limit, fakePages(rows, limit, &calls),
+		func(row paginationRow) int64 { return row.Time },
+		func(row paginationRow) string { return row.Key },
+	)
+	for row, err := range seq {
+		if err != nil {
+			t.Fatalf("paginate() error = %v", err)
+		}
+		result = append(result, row)
+	}
+	return result, calls
+}
+
+func TestPaginate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rows      []paginationRow
+		limit     int
+		startTime int64
+		endTime   int64
+		expected  int
+	}{
+		{
+			name:      "Single page",
+			rows:      []paginationRow{{1, "a"}, {2, "b"}},
+			limit:     3,
+			startTime: 0,
+			endTime:   10,
+			expected:  2,
+		},
+		{
+			name:      "Distinct timestamps",
+			rows:      []paginationRow{{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"}, {5, "e"}, {6, "f"}, {7, "g"}},
+			limit:     3,
+			startTime: 0,
+			endTime:   10,
+			expected:  7,
+		},
+		{
+			name:      "Shared boundary timestamp",
+			rows:      []paginationRow{{1, "a"}, {2, "b"}, {3, "c"}, {3, "d"}, {4, "e"}, {4, "f"}, {5, "g"}},
+			limit:     3,
+			startTime: 0,
+			endTime:   10,
+			expected:  7,
+		},
+		{
+			name:      "Window end",
+			rows:      []paginationRow{{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"}, {5, "e"}},
+			limit:     2,
+			startTime: 2,
+			endTime:   4,
+			expected:  3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, calls := collectRows(t, tc.rows, tc.limit, tc.startTime, tc.endTime)
+			if len(res) != tc.expected {
+				t.Errorf("paginate() len = %v, want %v (%+v)", len(res), tc.expected, res)
+			}
+			keys := make(map[string]bool)
+			for _, row := range res {
+				if keys[row.Key] {
+					t.Errorf("paginate() duplicate row %+v", row)
+				}
+				keys[row.Key] = true
+			}
+			if calls > len(tc.rows)+1 {
+				t.Errorf("paginate() calls = %v, want <= %v", calls, len(tc.rows)+1)
+			}
+		})
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	seq := paginate(0, 10, 2,
+		func(int64, int64) ([]paginationRow, error) { return nil, fetchErr },
+		func(row paginationRow) int64 { return row.Time },
+		func(row paginationRow) string { return row.Key },
+	)
+	for _, err := range seq {
+		if !errors.Is(err, fetchErr) {
+			t.Errorf("paginate() error = %v, want %v", err, fetchErr)
+		}
+	}
+}
+
+func TestPaginate_TooManyRowsAtTimestamp(t *testing.T) {
+	// pageLimit+1 rows share one timestamp, the last one cannot be requested
+	rows := []paginationRow{{1, "a"}, {2, "b"}, {2, "c"}, {2, "d"}, {2, "e"}, {3, "f"}}
+	calls := 0
+	seq := paginate(0, 10, 3, fakePages(rows, 3, &calls),
+		func(row paginationRow) int64 { return row.Time },
+		func(row paginationRow) string { return row.Key },
+	)
+	var keys []string
+	var err error
+	for row, rowErr := range seq {
+		if rowErr != nil {
+			err = rowErr
+			break
+		}
+		keys = append(keys, row.Key)
+	}
+	want := "3 rows or more at timestamp 2, cannot paginate past them"
+	if err == nil || err.Error() != want {
+		t.Errorf("paginate() error = %v, want %v", err, want)
+	}
+	if len(keys) != 4 {
+		t.Errorf("paginate() rows = %v, want the 4 rows before the error", keys)
+	}
+}
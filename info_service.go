@@ -1,8 +1,6 @@
 package hyperliquid
 
 import (
-	"encoding/json"
-	"fmt"
 	"strconv"
 )
 
@@ -17,18 +15,44 @@ type IInfoAPI interface {
 	GetUserFills(address string) (*[]OrderFill, error)
 	GetAccountFills() (*[]OrderFill, error)
 	GetUserRateLimits(address string) (*float64, error)
+	GetUserFees(address string) (*UserFees, error)
+	GetPortfolio(address string) (*Portfolio, error)
+	GetReferralState(address string) (*ReferralState, error)
 	GetL2BookSnapshot(coin string) (*L2BookSnapshot, error)
+	GetL2BookSnapshotAggregated(coin string, nSigFigs int, mantissa int) (*L2BookSnapshot, error)
+	GetL2Book(coin string, nSigFigs int, mantissa int) (*Book, error)
 	GetCandleSnapshot(coin string, interval string, startTime int64, endTime int64) (*CandleSnapshot, error)
 
 	// PERPETUALS INFO API ENDPOINTS
 	GetMeta() (*Meta, error)
+	GetMetaAndAssetCtxs() (*[]PerpAssetCtx, error)
 	GetUserState(address string) (*UserState, error)
+	GetActiveAssetData(address string, coin string) (*ActiveAssetData, error)
 	GetAccountState() (*UserState, error)
 	GetFundingUpdates(address string, startTime int64, endTime int64) (*[]FundingUpdate, error)
 	GetAccountFundingUpdates(startTime int64, endTime int64) (*[]FundingUpdate, error)
+	GetSubAccounts(address string) (*[]SubAccount, error)
+	GetVaultDetails(vaultAddress string, user string) (*VaultDetails, error)
+	GetUserVaultEquities(address string) (*[]VaultEquity, error)
 	GetNonFundingUpdates(address string, startTime int64, endTime int64) (*[]NonFundingUpdate, error)
 	GetAccountNonFundingUpdates(startTime int64, endTime int64) (*[]NonFundingUpdate, error)
 	GetHistoricalFundingRates() (*[]HistoricalFundingRate, error)
+	GetPredictedFundings() (*[]PredictedFunding, error)
+
+	// ORDER STATUS INFO API ENDPOINTS
+	GetOrderStatus(address string, oid int64) (*OrderStatusResponse, error)
+	GetOrderStatusByCloid(address string, cloid string) (*OrderStatusResponse, error)
+	GetAccountOrderStatus(oid int64) (*OrderStatusResponse, error)
+	GetAccountOrderStatusByCloid(cloid string) (*OrderStatusResponse, error)
+	GetHistoricalOrders(address string) (*[]OrderStatusInfo, error)
+	GetFrontendOpenOrders(address string) (*[]FrontendOrder, error)
+	GetUserFillsByTime(address string, startTime int64, endTime int64, aggregateByTime bool) (*[]OrderFill, error)
+
+	// STAKING INFO API ENDPOINTS
+	GetDelegations(address string) (*[]Delegation, error)
+	GetDelegatorSummary(address string) (*DelegatorSummary, error)
+	GetDelegatorHistory(address string) (*[]DelegatorHistoryEntry, error)
+	GetDelegatorRewards(address string) (*[]DelegatorReward, error)
 
 	// Additional helper functions
 	GetMartketPx(coin string) (float64, error)
@@ -73,41 +97,29 @@ func (api *InfoAPI) GetAllMids() (*map[string]string, error) {
 	return MakeUniversalRequest[map[string]string](api, request)
 }
 
-// Retrieve spot meta and asset contexts
-// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/spot#retrieve-spot-asset-contexts
+// Retrieve mid prices of all spot markets
+// The prices are taken from the spot asset contexts, see GetSpotMetaAndAssetCtxs
 func (api *InfoAPI) GetAllSpotPrices() (*map[string]string, error) {
-	request := InfoRequest{
-		Typez: "spotMetaAndAssetCtxs",
-	}
-	response, err := MakeUniversalRequest[SpotMetaAndAssetCtxsResponse](api, request)
+	response, err := api.GetSpotMetaAndAssetCtxs()
 	if err != nil {
 		return nil, err
 	}
-
-	marketsData, ok := response[1].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid markets data format")
-	}
-
 	result := make(map[string]string)
-
-	marketBytes, err := json.Marshal(marketsData)
-	if err != nil {
-		return nil, err
-	}
-
-	var markets []Market
-	if err := json.Unmarshal(marketBytes, &markets); err != nil {
-		return nil, err
-	}
-
-	for _, market := range markets {
+	for _, market := range response.AssetCtxs {
 		result[market.Coin] = market.MidPx
 	}
-
 	return &result, nil
 }
 
+// Retrieve spot meta and asset contexts
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/spot#retrieve-spot-asset-contexts
+func (api *InfoAPI) GetSpotMetaAndAssetCtxs() (*SpotMetaAndAssetCtxsResponse, error) {
+	request := InfoRequest{
+		Typez: "spotMetaAndAssetCtxs",
+	}
+	return MakeUniversalRequest[SpotMetaAndAssetCtxsResponse](api, request)
+}
+
 // Retrieve a user's open orders
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-open-orders
 func (api *InfoAPI) GetOpenOrders(address string) (*[]Order, error) {
@@ -125,6 +137,40 @@ func (api *InfoAPI) GetAccountOpenOrders() (*[]Order, error) {
 	return api.GetOpenOrders(api.AccountAddress())
 }
 
+// Retrieve a user's open orders with additional frontend info
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-open-orders-with-additional-frontend-info
+func (api *InfoAPI) GetFrontendOpenOrders(address string) (*[]FrontendOrder, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "frontendOpenOrders",
+	}
+	return MakeUniversalRequest[[]FrontendOrder](api, request)
+}
+
+// Retrieve a account's open orders with additional frontend info
+// The same as GetFrontendOpenOrders but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountFrontendOpenOrders() (*[]FrontendOrder, error) {
+	return api.GetFrontendOpenOrders(api.AccountAddress())
+}
+
+// Retrieve a user's historical orders (at most 2000 most recent orders)
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-historical-orders
+func (api *InfoAPI) GetHistoricalOrders(address string) (*[]OrderStatusInfo, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "historicalOrders",
+	}
+	return MakeUniversalRequest[[]OrderStatusInfo](api, request)
+}
+
+// Retrieve a account's historical orders
+// The same as GetHistoricalOrders but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountHistoricalOrders() (*[]OrderStatusInfo, error) {
+	return api.GetHistoricalOrders(api.AccountAddress())
+}
+
 // Retrieve a user's fills
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-fills
 func (api *InfoAPI) GetUserFills(address string) (*[]OrderFill, error) {
@@ -142,6 +188,64 @@ func (api *InfoAPI) GetAccountFills() (*[]OrderFill, error) {
 	return api.GetUserFills(api.AccountAddress())
 }
 
+// Query order status by oid
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-order-status-by-oid-or-cloid
+func (api *InfoAPI) GetOrderStatus(address string, oid int64) (*OrderStatusResponse, error) {
+	request := OrderStatusRequest{
+		User:  address,
+		Typez: "orderStatus",
+		Oid:   oid,
+	}
+	return MakeUniversalRequest[OrderStatusResponse](api, request)
+}
+
+// Query order status by cloid (Client Order ID)
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-order-status-by-oid-or-cloid
+func (api *InfoAPI) GetOrderStatusByCloid(address string, cloid string) (*OrderStatusResponse, error) {
+	request := OrderStatusRequest{
+		User:  address,
+		Typez: "orderStatus",
+		Oid:   cloid,
+	}
+	return MakeUniversalRequest[OrderStatusResponse](api, request)
+}
+
+// Query account's order status by oid
+// The same as GetOrderStatus but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountOrderStatus(oid int64) (*OrderStatusResponse, error) {
+	return api.GetOrderStatus(api.AccountAddress(), oid)
+}
+
+// Query account's order status by cloid
+// The same as GetOrderStatusByCloid but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountOrderStatusByCloid(cloid string) (*OrderStatusResponse, error) {
+	return api.GetOrderStatusByCloid(api.AccountAddress(), cloid)
+}
+
+// Retrieve a user's fills by time (at most 2000 fills per response)
+// If aggregateByTime is true, partial fills of the same crossing order are combined.
+// endTime can be 0 to query up to the current time.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-fills-by-time
+func (api *InfoAPI) GetUserFillsByTime(address string, startTime int64, endTime int64, aggregateByTime bool) (*[]OrderFill, error) {
+	request := InfoRequest{
+		User:            address,
+		Typez:           "userFillsByTime",
+		StartTime:       startTime,
+		EndTime:         endTime,
+		AggregateByTime: aggregateByTime,
+	}
+	return MakeUniversalRequest[[]OrderFill](api, request)
+}
+
+// Retrieve a account's fills by time
+// The same as GetUserFillsByTime but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountFillsByTime(startTime int64, endTime int64, aggregateByTime bool) (*[]OrderFill, error) {
+	return api.GetUserFillsByTime(api.AccountAddress(), startTime, endTime, aggregateByTime)
+}
+
 // Query user rate limits
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-user-rate-limits
 func (api *InfoAPI) GetUserRateLimits(address string) (*RatesLimits, error) {
@@ -159,6 +263,58 @@ func (api *InfoAPI) GetAccountRateLimits() (*RatesLimits, error) {
 	return api.GetUserRateLimits(api.AccountAddress())
 }
 
+// Query a user's fees
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-a-users-fees
+func (api *InfoAPI) GetUserFees(address string) (*UserFees, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "userFees",
+	}
+	return MakeUniversalRequest[UserFees](api, request)
+}
+
+// Query account's fees
+// The same as GetUserFees but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountFees() (*UserFees, error) {
+	return api.GetUserFees(api.AccountAddress())
+}
+
+// Query a user's portfolio
+// Returns the account value and PnL history for every window, see PortfolioDay and others
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-a-users-portfolio
+func (api *InfoAPI) GetPortfolio(address string) (*Portfolio, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "portfolio",
+	}
+	return MakeUniversalRequest[Portfolio](api, request)
+}
+
+// Query account's portfolio
+// The same as GetPortfolio but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountPortfolio() (*Portfolio, error) {
+	return api.GetPortfolio(api.AccountAddress())
+}
+
+// Query a user's referral information
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-a-users-referral-information
+func (api *InfoAPI) GetReferralState(address string) (*ReferralState, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "referral",
+	}
+	return MakeUniversalRequest[ReferralState](api, request)
+}
+
+// Query account's referral information
+// The same as GetReferralState but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountReferralState() (*ReferralState, error) {
+	return api.GetReferralState(api.AccountAddress())
+}
+
 // L2 Book snapshot
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#l2-book-snapshot
 func (api *InfoAPI) GetL2BookSnapshot(coin string) (*L2BookSnapshot, error) {
@@ -169,6 +325,33 @@ func (api *InfoAPI) GetL2BookSnapshot(coin string) (*L2BookSnapshot, error) {
 	return MakeUniversalRequest[L2BookSnapshot](api, request)
 }
 
+// L2 Book snapshot with price levels aggregated to nSigFigs significant figures
+// nSigFigs can be 2, 3, 4, 5 or 0 for full precision.
+// mantissa can only be set with nSigFigs = 5 and must be 1, 2 or 5 (0 to omit it).
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#l2-book-snapshot
+func (api *InfoAPI) GetL2BookSnapshotAggregated(coin string, nSigFigs int, mantissa int) (*L2BookSnapshot, error) {
+	if mantissa != 0 && nSigFigs != 5 {
+		return nil, APIError{Message: "mantissa can only be set when nSigFigs is 5"}
+	}
+	request := InfoRequest{
+		Typez:    "l2Book",
+		Coin:     coin,
+		NSigFigs: nSigFigs,
+		Mantissa: mantissa,
+	}
+	return MakeUniversalRequest[L2BookSnapshot](api, request)
+}
+
+// Helper function to get the order book of a coin with named bid and ask sides
+// nSigFigs and mantissa can be 0 to get the full precision book.
+func (api *InfoAPI) GetL2Book(coin string, nSigFigs int, mantissa int) (*Book, error) {
+	snapshot, err := api.GetL2BookSnapshotAggregated(coin, nSigFigs, mantissa)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Book(), nil
+}
+
 // Candle snapshot (Only the most recent 5000 candles are available)
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#candle-snapshot
 func (api *InfoAPI) GetCandleSnapshot(coin string, interval string, startTime int64, endTime int64) (*[]CandleSnapshot, error) {
@@ -193,6 +376,21 @@ func (api *InfoAPI) GetMeta() (*Meta, error) {
 	return MakeUniversalRequest[Meta](api, request)
 }
 
+// Retrieve perpetuals asset contexts (includes mark price, current funding, open interest, etc.)
+// Every asset of the universe is returned together with its context.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-perpetuals-asset-contexts-includes-mark-price-current-funding-open-interest-etc
+func (api *InfoAPI) GetMetaAndAssetCtxs() (*[]PerpAssetCtx, error) {
+	request := InfoRequest{
+		Typez: "metaAndAssetCtxs",
+	}
+	response, err := MakeUniversalRequest[MetaAndAssetCtxsResponse](api, request)
+	if err != nil {
+		return nil, err
+	}
+	result := response.Zip()
+	return &result, nil
+}
+
 // Retrieve spot metadata
 func (api *InfoAPI) GetSpotMeta() (*SpotMeta, error) {
 	request := InfoRequest{
@@ -218,6 +416,24 @@ func (api *InfoAPI) GetAccountState() (*UserState, error) {
 	return api.GetUserState(api.AccountAddress())
 }
 
+// Retrieve a user's active asset data (leverage and max trade sizes of a perpetual coin)
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-users-active-asset-data
+func (api *InfoAPI) GetActiveAssetData(address string, coin string) (*ActiveAssetData, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "activeAssetData",
+		Coin:  coin,
+	}
+	return MakeUniversalRequest[ActiveAssetData](api, request)
+}
+
+// Retrieve account's active asset data
+// The same as GetActiveAssetData but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountActiveAssetData(coin string) (*ActiveAssetData, error) {
+	return api.GetActiveAssetData(api.AccountAddress(), coin)
+}
+
 // Retrieve user's spot account summary
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/spot#retrieve-a-users-token-balances
 func (api *InfoAPI) GetUserStateSpot(address string) (*UserStateSpot, error) {
@@ -235,6 +451,53 @@ func (api *InfoAPI) GetAccountStateSpot() (*UserStateSpot, error) {
 	return api.GetUserStateSpot(api.AccountAddress())
 }
 
+// Retrieve a user's sub-accounts
+// The response is empty if the user has no sub-accounts.
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-subaccounts
+func (api *InfoAPI) GetSubAccounts(address string) (*[]SubAccount, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "subAccounts",
+	}
+	return MakeUniversalRequest[[]SubAccount](api, request)
+}
+
+// Retrieve account's sub-accounts
+// The same as GetSubAccounts but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountSubAccounts() (*[]SubAccount, error) {
+	return api.GetSubAccounts(api.AccountAddress())
+}
+
+// Retrieve details for a vault
+// user is optional, if set the response contains the follower state of the user
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-details-for-a-vault
+func (api *InfoAPI) GetVaultDetails(vaultAddress string, user string) (*VaultDetails, error) {
+	request := InfoRequest{
+		User:         user,
+		Typez:        "vaultDetails",
+		VaultAddress: vaultAddress,
+	}
+	return MakeUniversalRequest[VaultDetails](api, request)
+}
+
+// Retrieve a user's vault deposits
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#retrieve-a-users-vault-deposits
+func (api *InfoAPI) GetUserVaultEquities(address string) (*[]VaultEquity, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "userVaultEquities",
+	}
+	return MakeUniversalRequest[[]VaultEquity](api, request)
+}
+
+// Retrieve account's vault deposits
+// The same as GetUserVaultEquities but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountVaultEquities() (*[]VaultEquity, error) {
+	return api.GetUserVaultEquities(api.AccountAddress())
+}
+
 // Retrieve a user's funding history
 // https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-a-users-funding-history-or-non-funding-ledger-updates
 func (api *InfoAPI) GetFundingUpdates(address string, startTime int64, endTime int64) (*[]FundingUpdate, error) {
@@ -285,6 +548,83 @@ func (api *InfoAPI) GetHistoricalFundingRates(coin string, startTime int64, endT
 	return MakeUniversalRequest[[]HistoricalFundingRate](api, request)
 }
 
+// Retrieve predicted funding rates for different venues
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint/perpetuals#retrieve-predicted-funding-rates-for-different-venues
+func (api *InfoAPI) GetPredictedFundings() (*[]PredictedFunding, error) {
+	request := InfoRequest{
+		Typez: "predictedFundings",
+	}
+	return MakeUniversalRequest[[]PredictedFunding](api, request)
+}
+
+// Query a user's staking delegations
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-a-users-staking-delegations
+func (api *InfoAPI) GetDelegations(address string) (*[]Delegation, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "delegations",
+	}
+	return MakeUniversalRequest[[]Delegation](api, request)
+}
+
+// Query account's staking delegations
+// The same as GetDelegations but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountDelegations() (*[]Delegation, error) {
+	return api.GetDelegations(api.AccountAddress())
+}
+
+// Query a user's staking summary
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-a-users-staking-summary
+func (api *InfoAPI) GetDelegatorSummary(address string) (*DelegatorSummary, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "delegatorSummary",
+	}
+	return MakeUniversalRequest[DelegatorSummary](api, request)
+}
+
+// Query account's staking summary
+// The same as GetDelegatorSummary but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountDelegatorSummary() (*DelegatorSummary, error) {
+	return api.GetDelegatorSummary(api.AccountAddress())
+}
+
+// Query a user's staking history
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-a-users-staking-history
+func (api *InfoAPI) GetDelegatorHistory(address string) (*[]DelegatorHistoryEntry, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "delegatorHistory",
+	}
+	return MakeUniversalRequest[[]DelegatorHistoryEntry](api, request)
+}
+
+// Query account's staking history
+// The same as GetDelegatorHistory but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountDelegatorHistory() (*[]DelegatorHistoryEntry, error) {
+	return api.GetDelegatorHistory(api.AccountAddress())
+}
+
+// Query a user's staking rewards
+// https://hyperliquid.gitbook.io/hyperliquid-docs/for-developers/api/info-endpoint#query-a-users-staking-rewards
+func (api *InfoAPI) GetDelegatorRewards(address string) (*[]DelegatorReward, error) {
+	request := InfoRequest{
+		User:  address,
+		Typez: "delegatorRewards",
+	}
+	return MakeUniversalRequest[[]DelegatorReward](api, request)
+}
+
+// Query account's staking rewards
+// The same as GetDelegatorRewards but user is set to the account address
+// Check AccountAddress() or SetAccountAddress() if there is a need to set the account address
+func (api *InfoAPI) GetAccountDelegatorRewards() (*[]DelegatorReward, error) {
+	return api.GetDelegatorRewards(api.AccountAddress())
+}
+
 // Helper function to get the market price of a given coin
 // The coin parameter is the name of the coin
 //
@@ -323,15 +663,20 @@ func (api *InfoAPI) GetSpotMarketPx(coin string) (float64, error) {
 }
 
 // Helper function to get the withdrawals of a given address
-// By default returns last 90 days
+// By default returns last 90 days, use GetWithdrawalsByTime for a custom time range
 func (api *InfoAPI) GetWithdrawals(address string) (*[]Withdrawal, error) {
 	startTime, endTime := GetDefaultTimeRange()
-	updates, err := api.GetNonFundingUpdates(address, startTime, endTime)
-	if err != nil {
-		return nil, err
-	}
+	return api.GetWithdrawalsByTime(address, startTime, endTime)
+}
+
+// Helper function to get the withdrawals of a given address in the given time range
+// All pages of non-funding ledger updates are requested, so the result is not truncated.
+func (api *InfoAPI) GetWithdrawalsByTime(address string, startTime int64, endTime int64) (*[]Withdrawal, error) {
 	var withdrawals []Withdrawal
-	for _, update := range *updates {
+	for update, err := range api.IterNonFundingUpdates(address, startTime, endTime) {
+		if err != nil {
+			return nil, err
+		}
 		if update.Delta.Type == "withdraw" {
 			withrawal := Withdrawal{
 				Time:   update.Time,
@@ -354,15 +699,20 @@ func (api *InfoAPI) GetAccountWithdrawals() (*[]Withdrawal, error) {
 }
 
 // Helper function to get the deposits of the given address
-// By default returns last 90 days
+// By default returns last 90 days, use GetDepositsByTime for a custom time range
 func (api *InfoAPI) GetDeposits(address string) (*[]Deposit, error) {
 	startTime, endTime := GetDefaultTimeRange()
-	updates, err := api.GetNonFundingUpdates(address, startTime, endTime)
-	if err != nil {
-		return nil, err
-	}
+	return api.GetDepositsByTime(address, startTime, endTime)
+}
+
+// Helper function to get the deposits of the given address in the given time range
+// All pages of non-funding ledger updates are requested, so the result is not truncated.
+func (api *InfoAPI) GetDepositsByTime(address string, startTime int64, endTime int64) (*[]Deposit, error) {
 	var deposits []Deposit
-	for _, update := range *updates {
+	for update, err := range api.IterNonFundingUpdates(address, startTime, endTime) {
+		if err != nil {
+			return nil, err
+		}
 		if update.Delta.Type == "deposit" {
 			deposit := Deposit{
 				Hash:   update.Hash,
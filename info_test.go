@@ -4,8 +4,10 @@
 package hyperliquid
 
 import (
+       "math"
        "os"
        "testing"
+       "time"
 )
 
 func GetInfoAPI(t *testing.T) *InfoAPI {
@@ -366,3 +368,259 @@ func TestInfoAPI_GetUserStateSpot(t *testing.T) {
 	}
 	t.Logf("GetUserStateSpot() = %+v", res)
 }
+
+func TestInfoAPI_GetAccountOrderStatus(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountOrderStatus(1)
+	if err != nil {
+		t.Errorf("GetAccountOrderStatus() error = %v", err)
+	}
+	if !res.IsUnknown() {
+		t.Errorf("GetAccountOrderStatus() = %+v, want unknownOid", res)
+	}
+	res, err = api.GetAccountOrderStatusByCloid(GetRandomCloid())
+	if err != nil {
+		t.Errorf("GetAccountOrderStatusByCloid() error = %v", err)
+	}
+	if !res.IsUnknown() {
+		t.Errorf("GetAccountOrderStatusByCloid() = %+v, want unknownOid", res)
+	}
+	t.Logf("GetAccountOrderStatusByCloid() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountFrontendOpenOrders(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountFrontendOpenOrders()
+	if err != nil {
+		t.Errorf("GetAccountFrontendOpenOrders() error = %v", err)
+	}
+	t.Logf("GetAccountFrontendOpenOrders() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountHistoricalOrders(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountHistoricalOrders()
+	if err != nil {
+		t.Errorf("GetAccountHistoricalOrders() error = %v", err)
+	}
+	if len(*res) == 0 {
+		t.Errorf("GetAccountHistoricalOrders() len = %v, want > %v", res, 0)
+	}
+	for _, order := range *res {
+		if order.Status == "" {
+			t.Errorf("order.Status = %v, want not empty", order.Status)
+		}
+	}
+	t.Logf("GetAccountHistoricalOrders() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountFillsByTime(t *testing.T) {
+	api := GetInfoAPI(t)
+	startTime, endTime := GetDefaultTimeRange()
+	res, err := api.GetAccountFillsByTime(startTime, endTime, true)
+	if err != nil {
+		t.Errorf("GetAccountFillsByTime() error = %v", err)
+	}
+	for _, fill := range *res {
+		if fill.Time < startTime || fill.Time > endTime {
+			t.Errorf("fill.Time = %v, want in [%v, %v]", fill.Time, startTime, endTime)
+		}
+	}
+	t.Logf("GetAccountFillsByTime() = %+v", res)
+}
+
+func TestInfoAPI_IterCandles(t *testing.T) {
+	api := GetInfoAPI(t)
+	// Only the 5000 most recent candles are kept, stay well inside the retained range
+	interval := 15 * time.Minute
+	endTime := time.Now().UnixMilli()
+	startTime := endTime - (36 * time.Hour).Milliseconds()
+	count := 0
+	var last int64
+	for candle, err := range api.IterCandles("ETH", "15m", startTime, endTime) {
+		if err != nil {
+			t.Fatalf("IterCandles() error = %v", err)
+		}
+		// Candles must be contiguous: no duplicate or missing open time between pages
+		if last != 0 && candle.CloseTime != last+interval.Milliseconds() {
+			t.Errorf("candle.CloseTime = %v, want %v", candle.CloseTime, last+interval.Milliseconds())
+		}
+		last = candle.CloseTime
+		count++
+	}
+	want := int((endTime - startTime) / interval.Milliseconds())
+	if count < want || count > want+1 {
+		t.Errorf("IterCandles() count = %v, want %v or %v", count, want, want+1)
+	}
+}
+
+func TestInfoAPI_GetMetaAndAssetCtxs(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetMetaAndAssetCtxs()
+	if err != nil {
+		t.Fatalf("GetMetaAndAssetCtxs() error = %v", err)
+	}
+	if len(*res) == 0 {
+		t.Fatalf("GetMetaAndAssetCtxs() len = %v, want > %v", res, 0)
+	}
+	btc := (*res)[0]
+	if btc.Name != "BTC" {
+		t.Errorf("res[0].Name = %v, want %v", btc.Name, "BTC")
+	}
+	if btc.MarkPx <= 0 || btc.OraclePx <= 0 {
+		t.Errorf("res[0].Context = %+v, want prices > 0", btc.Context)
+	}
+	t.Logf("GetMetaAndAssetCtxs() = %+v", btc)
+}
+
+func TestInfoAPI_GetPredictedFundings(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetPredictedFundings()
+	if err != nil {
+		t.Fatalf("GetPredictedFundings() error = %v", err)
+	}
+	if len(*res) == 0 {
+		t.Errorf("GetPredictedFundings() len = %v, want > %v", res, 0)
+	}
+	for _, funding := range *res {
+		if _, ok := funding.Venue(VenueHyperliquid); !ok {
+			t.Errorf("funding.Venue(%s) not found for %s", VenueHyperliquid, funding.Coin)
+		}
+	}
+}
+
+func TestInfoAPI_ProjectAccountNextFundingPayments(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.ProjectAccountNextFundingPayments()
+	if err != nil {
+		t.Fatalf("ProjectAccountNextFundingPayments() error = %v", err)
+	}
+	t.Logf("ProjectAccountNextFundingPayments() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountSubAccounts(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountSubAccounts()
+	if err != nil {
+		t.Fatalf("GetAccountSubAccounts() error = %v", err)
+	}
+	for _, subAccount := range *res {
+		if subAccount.SubAccountUser == "" {
+			t.Errorf("subAccount.SubAccountUser is empty for %s", subAccount.Name)
+		}
+	}
+	t.Logf("GetAccountSubAccounts() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountVaultEquities(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountVaultEquities()
+	if err != nil {
+		t.Fatalf("GetAccountVaultEquities() error = %v", err)
+	}
+	for _, equity := range *res {
+		details, err := api.GetVaultDetails(equity.VaultAddress, api.AccountAddress())
+		if err != nil {
+			t.Errorf("GetVaultDetails() error = %v", err)
+			continue
+		}
+		if details.FollowerState == nil {
+			t.Errorf("details.FollowerState is nil for vault %s", equity.VaultAddress)
+		}
+	}
+	t.Logf("GetAccountVaultEquities() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountStaking(t *testing.T) {
+	api := GetInfoAPI(t)
+	summary, err := api.GetAccountDelegatorSummary()
+	if err != nil {
+		t.Fatalf("GetAccountDelegatorSummary() error = %v", err)
+	}
+	delegations, err := api.GetAccountDelegations()
+	if err != nil {
+		t.Fatalf("GetAccountDelegations() error = %v", err)
+	}
+	delegated := 0.0
+	for _, delegation := range *delegations {
+		delegated += delegation.Amount
+	}
+	if math.Abs(delegated-summary.Delegated) > 1e-8 {
+		t.Errorf("sum of delegations = %v, want %v", delegated, summary.Delegated)
+	}
+	if _, err := api.GetAccountDelegatorHistory(); err != nil {
+		t.Errorf("GetAccountDelegatorHistory() error = %v", err)
+	}
+	if _, err := api.GetAccountDelegatorRewards(); err != nil {
+		t.Errorf("GetAccountDelegatorRewards() error = %v", err)
+	}
+	t.Logf("GetAccountDelegatorSummary() = %+v", summary)
+}
+
+func TestInfoAPI_GetAccountFees(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountFees()
+	if err != nil {
+		t.Fatalf("GetAccountFees() error = %v", err)
+	}
+	if res.UserCrossRate <= 0 {
+		t.Errorf("res.UserCrossRate = %v, want > %v", res.UserCrossRate, 0)
+	}
+	t.Logf("GetAccountFees() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountPortfolio(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountPortfolio()
+	if err != nil {
+		t.Fatalf("GetAccountPortfolio() error = %v", err)
+	}
+	for _, window := range []string{PortfolioDay, PortfolioWeek, PortfolioMonth, PortfolioAllTime, PortfolioPerpAllTime} {
+		if _, ok := (*res)[window]; !ok {
+			t.Errorf("GetAccountPortfolio() window %s not found", window)
+		}
+	}
+	allTime := (*res)[PortfolioAllTime]
+	t.Logf("account value = %v, pnl = %v", allTime.LatestAccountValue(), allTime.Pnl())
+}
+
+func TestInfoAPI_GetAccountReferralState(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountReferralState()
+	if err != nil {
+		t.Fatalf("GetAccountReferralState() error = %v", err)
+	}
+	switch res.ReferrerState.Stage {
+	case ReferrerStageReady, ReferrerStageNeedToCreateCode, ReferrerStageNeedToTrade:
+	default:
+		t.Errorf("res.ReferrerState.Stage = %v, want a known stage", res.ReferrerState.Stage)
+	}
+	t.Logf("GetAccountReferralState() = %+v", res)
+}
+
+func TestInfoAPI_GetAccountActiveAssetData(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetAccountActiveAssetData("ETH")
+	if err != nil {
+		t.Fatalf("GetAccountActiveAssetData() error = %v", err)
+	}
+	if res.Coin != "ETH" || res.Leverage.Value == 0 {
+		t.Errorf("GetAccountActiveAssetData() = %+v", res)
+	}
+	t.Logf("GetAccountActiveAssetData() = %+v", res)
+}
+
+func TestInfoAPI_GetL2Book(t *testing.T) {
+	api := GetInfoAPI(t)
+	res, err := api.GetL2Book("BTC", 2, 0)
+	if err != nil {
+		t.Fatalf("GetL2Book() error = %v", err)
+	}
+	if len(res.Bids) == 0 || len(res.Asks) == 0 {
+		t.Fatalf("GetL2Book() = %+v, want both sides", res)
+	}
+	if res.Bids[0].Px >= res.Asks[0].Px {
+		t.Errorf("GetL2Book() crossed book, bid %v >= ask %v", res.Bids[0].Px, res.Asks[0].Px)
+	}
+	t.Logf("GetL2Book() mid = %v, spread = %v bps", res.Mid(), res.SpreadBps())
+}
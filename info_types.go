@@ -1,5 +1,12 @@
 package hyperliquid
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // Base request for /info
 type InfoRequest struct {
 	User      string `json:"user,omitempty"`
@@ -8,6 +15,11 @@ type InfoRequest struct {
 	Coin      string `json:"coin,omitempty"`
 	StartTime int64  `json:"startTime,omitempty"`
 	EndTime   int64  `json:"endTime,omitempty"`
+
+	AggregateByTime bool   `json:"aggregateByTime,omitempty"`
+	VaultAddress    string `json:"vaultAddress,omitempty"`
+	NSigFigs        int    `json:"nSigFigs,omitempty"`
+	Mantissa        int    `json:"mantissa,omitempty"`
 }
 
 type UserStateRequest struct {
@@ -20,6 +32,7 @@ type Asset struct {
 	SzDecimals   int    `json:"szDecimals"`
 	MaxLeverage  int    `json:"maxLeverage"`
 	OnlyIsolated bool   `json:"onlyIsolated"`
+	IsDelisted   bool   `json:"isDelisted,omitempty"`
 }
 
 type UserState struct {
@@ -58,6 +71,15 @@ type UserStateSpot struct {
 	Balances []SpotAssetPosition `json:"balances"`
 }
 
+// SubAccount is a sub-account of a master account with its perp and spot state.
+type SubAccount struct {
+	Name               string        `json:"name"`
+	SubAccountUser     string        `json:"subAccountUser"`
+	Master             string        `json:"master"`
+	ClearinghouseState UserState     `json:"clearinghouseState"`
+	SpotState          UserStateSpot `json:"spotState"`
+}
+
 type SpotAssetPosition struct {
 	/*
 			 "coin": "USDC",
@@ -92,9 +114,63 @@ type Order struct {
 	TriggerPx        float64 `json:"triggerPx,string,omitempty"`
 }
 
+// FrontendOrder is an order with the additional fields shown in the Hyperliquid UI.
+// Children holds the TP/SL orders attached to a parent order.
+type FrontendOrder struct {
+	Children         []FrontendOrder `json:"children"`
+	Cloid            string          `json:"cloid,omitempty"`
+	Coin             string          `json:"coin"`
+	IsPositionTpsl   bool            `json:"isPositionTpsl"`
+	IsTrigger        bool            `json:"isTrigger"`
+	LimitPx          float64         `json:"limitPx,string"`
+	Oid              int64           `json:"oid"`
+	OrderType        string          `json:"orderType"`
+	OrigSz           float64         `json:"origSz,string"`
+	ReduceOnly       bool            `json:"reduceOnly"`
+	Side             string          `json:"side"`
+	Sz               float64         `json:"sz,string"`
+	Tif              string          `json:"tif,omitempty"`
+	Timestamp        int64           `json:"timestamp"`
+	TriggerCondition string          `json:"triggerCondition"`
+	TriggerPx        float64         `json:"triggerPx,string"`
+}
+
+// Frontend order types
+const (
+	FrontendOrderTypeLimit            = "Limit"
+	FrontendOrderTypeMarket           = "Market"
+	FrontendOrderTypeStopMarket       = "Stop Market"
+	FrontendOrderTypeStopLimit        = "Stop Limit"
+	FrontendOrderTypeTakeProfitMarket = "Take Profit Market"
+	FrontendOrderTypeTakeProfitLimit  = "Take Profit Limit"
+)
+
+// TpSl returns whether a trigger order is a take profit or a stop loss.
+// It returns an empty value for orders that are not trigger orders.
+func (o *FrontendOrder) TpSl() TpSl {
+	switch o.OrderType {
+	case FrontendOrderTypeTakeProfitMarket, FrontendOrderTypeTakeProfitLimit:
+		return TriggerTp
+	case FrontendOrderTypeStopMarket, FrontendOrderTypeStopLimit:
+		return TriggerSl
+	}
+	return ""
+}
+
+// IsBuy returns true for bid side orders ("B").
+func (o *FrontendOrder) IsBuy() bool {
+	return o.Side == "B"
+}
+
+// IsMarketTrigger returns true for trigger orders that execute as market orders once triggered.
+func (o *FrontendOrder) IsMarketTrigger() bool {
+	return o.IsTrigger && (o.OrderType == FrontendOrderTypeStopMarket || o.OrderType == FrontendOrderTypeTakeProfitMarket)
+}
+
 type Leverage struct {
-	Type  string `json:"type"`
-	Value int    `json:"value"`
+	Type   string  `json:"type"`
+	Value  int     `json:"value"`
+	RawUsd float64 `json:"rawUsd,string,omitempty"` // only for isolated leverage
 }
 
 type MarginSummary struct {
@@ -146,33 +222,197 @@ type OrderFill struct {
 	Liquidation   *Liquidation `json:"liquidation"`
 }
 
+// Context is the asset context of a perpetual market.
+// Funding is the current hourly funding rate, MidPx and Premium are 0 when not available.
 type Context struct {
-	DayNtlVlm    string   `json:"dayNtlVlm"`
-	Funding      string   `json:"funding"`
-	ImpactPxs    []string `json:"impactPxs"`
-	MarkPx       string   `json:"markPx"`
-	MidPx        string   `json:"midPx"`
-	OpenInterest string   `json:"openInterest"`
-	OraclePx     string   `json:"oraclePx"`
-	Premium      string   `json:"premium"`
-	PrevDayPx    string   `json:"prevDayPx"`
+	DayNtlVlm    float64      `json:"dayNtlVlm,string"`
+	DayBaseVlm   float64      `json:"dayBaseVlm,string"`
+	Funding      float64      `json:"funding,string"`
+	ImpactPxs    FloatStrings `json:"impactPxs"`
+	MarkPx       float64      `json:"markPx,string"`
+	MidPx        float64      `json:"midPx,string"`
+	OpenInterest float64      `json:"openInterest,string"`
+	OraclePx     float64      `json:"oraclePx,string"`
+	Premium      float64      `json:"premium,string"`
+	PrevDayPx    float64      `json:"prevDayPx,string"`
+}
+
+// ImpactBidPx returns the impact bid price or 0 if not available.
+func (ctx *Context) ImpactBidPx() float64 {
+	if len(ctx.ImpactPxs) < 1 {
+		return 0
+	}
+	return ctx.ImpactPxs[0]
+}
+
+// ImpactAskPx returns the impact ask price or 0 if not available.
+func (ctx *Context) ImpactAskPx() float64 {
+	if len(ctx.ImpactPxs) < 2 {
+		return 0
+	}
+	return ctx.ImpactPxs[1]
+}
+
+// PerpAssetCtx is a perpetual asset of the universe with its context.
+type PerpAssetCtx struct {
+	AssetId int `json:"assetId"`
+	Asset
+	Context
+}
+
+// MetaAndAssetCtxsResponse is the response of the metaAndAssetCtxs info type.
+// AssetCtxs has the same order as Meta.Universe.
+type MetaAndAssetCtxsResponse struct {
+	Meta      Meta
+	AssetCtxs []Context
+}
+
+func (r *MetaAndAssetCtxsResponse) UnmarshalJSON(data []byte) error {
+	return unmarshalPair(data, &r.Meta, &r.AssetCtxs)
+}
+
+// Zip returns every asset of the universe together with its context.
+func (r *MetaAndAssetCtxsResponse) Zip() []PerpAssetCtx {
+	result := make([]PerpAssetCtx, 0, len(r.Meta.Universe))
+	for index, asset := range r.Meta.Universe {
+		if index >= len(r.AssetCtxs) {
+			break
+		}
+		result = append(result, PerpAssetCtx{
+			AssetId: index,
+			Asset:   asset,
+			Context: r.AssetCtxs[index],
+		})
+	}
+	return result
+}
+
+// FloatStrings is a list of floats encoded as strings by the API, e.g. ["1.5", "2.0"].
+type FloatStrings []float64
+
+func (f *FloatStrings) UnmarshalJSON(data []byte) error {
+	var raw []string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*f = nil
+		return nil
+	}
+	values := make(FloatStrings, len(raw))
+	for i, value := range raw {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		values[i] = parsed
+	}
+	*f = values
+	return nil
+}
+
+// unmarshalPair decodes a JSON array of exactly 2 elements into first and second.
+func unmarshalPair(data []byte, first any, second any) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("expected array of 2 elements, got %d", len(raw))
+	}
+	if err := json.Unmarshal(raw[0], first); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw[1], second)
 }
 
 type HistoricalFundingRate struct {
-	Coin        string `json:"coin"`
-	FundingRate string `json:"fundingRate"`
-	Premium     string `json:"premium"`
-	Time        int64  `json:"time"`
+	Coin        string  `json:"coin"`
+	FundingRate float64 `json:"fundingRate,string"`
+	Premium     float64 `json:"premium,string"`
+	Time        int64   `json:"time"`
+}
+
+// Venues returned by predictedFundings
+const (
+	VenueHyperliquid = "HlPerp"
+	VenueBinance     = "BinPerp"
+	VenueBybit       = "BybitPerp"
+)
+
+// VenueFunding is the predicted funding of a coin on a single venue.
+type VenueFunding struct {
+	Venue                string  `json:"venue"`
+	FundingRate          float64 `json:"fundingRate,string"`
+	NextFundingTime      int64   `json:"nextFundingTime"`
+	FundingIntervalHours int     `json:"fundingIntervalHours,omitempty"`
+}
+
+// PredictedFunding is the predicted funding of a coin across venues.
+// The API encodes it as ["BTC", [["BinPerp", {...}], ["HlPerp", {...}]]].
+type PredictedFunding struct {
+	Coin   string
+	Venues []VenueFunding
 }
 
+func (p *PredictedFunding) UnmarshalJSON(data []byte) error {
+	var venues []json.RawMessage
+	if err := unmarshalPair(data, &p.Coin, &venues); err != nil {
+		return err
+	}
+	p.Venues = make([]VenueFunding, 0, len(venues))
+	for _, raw := range venues {
+		var venue VenueFunding
+		var funding *VenueFunding
+		if err := unmarshalPair(raw, &venue.Venue, &funding); err != nil {
+			return err
+		}
+		// venues without a prediction are returned as null
+		if funding == nil {
+			continue
+		}
+		funding.Venue = venue.Venue
+		p.Venues = append(p.Venues, *funding)
+	}
+	return nil
+}
+
+// Venue returns the predicted funding on the given venue, e.g. VenueHyperliquid.
+func (p *PredictedFunding) Venue(venue string) (VenueFunding, bool) {
+	for _, funding := range p.Venues {
+		if funding.Venue == venue {
+			return funding, true
+		}
+	}
+	return VenueFunding{}, false
+}
+
+// BookLevel is a price level of the order book.
+// N is the number of orders resting at the level.
+type BookLevel struct {
+	Px float64 `json:"px,string"`
+	Sz float64 `json:"sz,string"`
+	N  int     `json:"n"`
+}
+
+// L2BookSnapshot is the raw l2Book response.
+// Levels[0] holds the bids and Levels[1] the asks, both sorted from the best price.
 type L2BookSnapshot struct {
-	Coin   string `json:"coin"`
-	Time   int64  `json:"time"`
-	Levels [][]struct {
-		Px float64 `json:"px,string"`
-		Sz float64 `json:"sz,string"`
-		N  int     `json:"n"`
-	} `json:"levels"`
+	Coin   string        `json:"coin"`
+	Time   int64         `json:"time"`
+	Levels [][]BookLevel `json:"levels"`
+}
+
+// Book returns the snapshot with bids and asks split into named sides.
+func (s *L2BookSnapshot) Book() *Book {
+	book := &Book{Coin: s.Coin, Time: s.Time}
+	if len(s.Levels) > 0 {
+		book.Bids = s.Levels[0]
+	}
+	if len(s.Levels) > 1 {
+		book.Asks = s.Levels[1]
+	}
+	return book
 }
 
 type CandleSnapshotSubRequest struct {
@@ -218,7 +458,15 @@ type RatesLimits struct {
 	NRequestsCap  int     `json:"nRequestsCap"`
 }
 
-type SpotMetaAndAssetCtxsResponse [2]interface{} // Array of exactly 2 elements
+// SpotMetaAndAssetCtxsResponse is the response of the spotMetaAndAssetCtxs info type.
+type SpotMetaAndAssetCtxsResponse struct {
+	Meta      SpotMeta
+	AssetCtxs []Market
+}
+
+func (r *SpotMetaAndAssetCtxsResponse) UnmarshalJSON(data []byte) error {
+	return unmarshalPair(data, &r.Meta, &r.AssetCtxs)
+}
 
 type Market struct {
 	PrevDayPx         string `json:"prevDayPx,omitempty"`
@@ -230,3 +478,416 @@ type Market struct {
 	TotalSupply       string `json:"totalSupply,omitempty"`
 	DayBaseVlm        string `json:"dayBaseVlm,omitempty"`
 }
+
+// OrderStatusRequest is the request for the orderStatus info type.
+// Oid is either the numeric order id or the cloid as a hex string.
+type OrderStatusRequest struct {
+	User  string `json:"user"`
+	Typez string `json:"type"`
+	Oid   any    `json:"oid"`
+}
+
+// OrderStatusValue is the lifecycle state of an order as reported by orderStatus.
+type OrderStatusValue string
+
+const (
+	OrderStatusOpen                    OrderStatusValue = "open"
+	OrderStatusFilled                  OrderStatusValue = "filled"
+	OrderStatusCanceled                OrderStatusValue = "canceled"
+	OrderStatusTriggered               OrderStatusValue = "triggered"
+	OrderStatusRejected                OrderStatusValue = "rejected"
+	OrderStatusMarginCanceled          OrderStatusValue = "marginCanceled"
+	OrderStatusVaultWithdrawalCanceled OrderStatusValue = "vaultWithdrawalCanceled"
+	OrderStatusOpenInterestCapCanceled OrderStatusValue = "openInterestCapCanceled"
+	OrderStatusSelfTradeCanceled       OrderStatusValue = "selfTradeCanceled"
+	OrderStatusReduceOnlyCanceled      OrderStatusValue = "reduceOnlyCanceled"
+	OrderStatusSiblingFilledCanceled   OrderStatusValue = "siblingFilledCanceled"
+	OrderStatusDelistedCanceled        OrderStatusValue = "delistedCanceled"
+	OrderStatusLiquidatedCanceled      OrderStatusValue = "liquidatedCanceled"
+	OrderStatusScheduledCancel         OrderStatusValue = "scheduledCancel"
+)
+
+// IsFinal reports whether the order can no longer change state.
+func (s OrderStatusValue) IsFinal() bool {
+	return s != OrderStatusOpen && s != OrderStatusTriggered
+}
+
+// IsCanceled reports whether the order was canceled, either by the user or by the exchange.
+// Rejections (statuses ending with "Rejected") are not cancellations.
+func (s OrderStatusValue) IsCanceled() bool {
+	return s == OrderStatusCanceled || s == OrderStatusScheduledCancel || strings.HasSuffix(string(s), "Canceled")
+}
+
+// IsRejected reports whether the order was rejected on placement.
+func (s OrderStatusValue) IsRejected() bool {
+	return s == OrderStatusRejected || strings.HasSuffix(string(s), "Rejected")
+}
+
+// OrderStatusInfo is an order together with its current status.
+type OrderStatusInfo struct {
+	Order           FrontendOrder    `json:"order"`
+	Status          OrderStatusValue `json:"status"`
+	StatusTimestamp int64            `json:"statusTimestamp"`
+}
+
+// OrderStatusResponse is the response of the orderStatus info type.
+// Status is "order" when the order is known and "unknownOid" otherwise.
+type OrderStatusResponse struct {
+	Status string           `json:"status"`
+	Order  *OrderStatusInfo `json:"order,omitempty"`
+}
+
+// IsUnknown reports whether the exchange has no record of the requested order.
+func (r *OrderStatusResponse) IsUnknown() bool {
+	return r.Status == "unknownOid" || r.Order == nil
+}
+
+// HistoryPoint is a single value of a time series, encoded by the API as [time, "value"].
+type HistoryPoint struct {
+	Time  int64
+	Value float64
+}
+
+func (p *HistoryPoint) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := unmarshalPair(data, &p.Time, &value); err != nil {
+		return err
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	p.Value = parsed
+	return nil
+}
+
+// PortfolioHistory is the performance of an account over a time window.
+type PortfolioHistory struct {
+	AccountValueHistory []HistoryPoint `json:"accountValueHistory"`
+	PnlHistory          []HistoryPoint `json:"pnlHistory"`
+	Vlm                 float64        `json:"vlm,string"`
+}
+
+// LatestAccountValue returns the last account value of the window or 0 if the history is empty.
+func (h *PortfolioHistory) LatestAccountValue() float64 {
+	if len(h.AccountValueHistory) == 0 {
+		return 0
+	}
+	return h.AccountValueHistory[len(h.AccountValueHistory)-1].Value
+}
+
+// Pnl returns the PnL accumulated over the window or 0 if the history is empty.
+func (h *PortfolioHistory) Pnl() float64 {
+	if len(h.PnlHistory) == 0 {
+		return 0
+	}
+	return h.PnlHistory[len(h.PnlHistory)-1].Value - h.PnlHistory[0].Value
+}
+
+// Portfolio time windows. The perp windows only account for perpetuals.
+const (
+	PortfolioDay         = "day"
+	PortfolioWeek        = "week"
+	PortfolioMonth       = "month"
+	PortfolioAllTime     = "allTime"
+	PortfolioPerpDay     = "perpDay"
+	PortfolioPerpWeek    = "perpWeek"
+	PortfolioPerpMonth   = "perpMonth"
+	PortfolioPerpAllTime = "perpAllTime"
+)
+
+// Portfolio maps a time window (e.g. PortfolioDay) to the performance over that window.
+// The API encodes it as [["day", {...}], ["week", {...}], ...].
+type Portfolio map[string]PortfolioHistory
+
+func (p *Portfolio) UnmarshalJSON(data []byte) error {
+	var windows []json.RawMessage
+	if err := json.Unmarshal(data, &windows); err != nil {
+		return err
+	}
+	portfolio := make(Portfolio, len(windows))
+	for _, raw := range windows {
+		var window string
+		var history PortfolioHistory
+		if err := unmarshalPair(raw, &window, &history); err != nil {
+			return err
+		}
+		portfolio[window] = history
+	}
+	*p = portfolio
+	return nil
+}
+
+// VaultFollower is a depositor of a vault.
+type VaultFollower struct {
+	User           string  `json:"user"`
+	VaultEquity    float64 `json:"vaultEquity,string"`
+	Pnl            float64 `json:"pnl,string"`
+	AllTimePnl     float64 `json:"allTimePnl,string"`
+	DaysFollowing  int     `json:"daysFollowing"`
+	VaultEntryTime int64   `json:"vaultEntryTime"`
+	LockupUntil    int64   `json:"lockupUntil"`
+}
+
+// VaultRelationship is "normal" for standalone vaults, "parent" or "child" otherwise.
+type VaultRelationship struct {
+	Type string `json:"type"`
+	Data *struct {
+		ChildAddresses []string `json:"childAddresses,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// VaultDetails is the response of the vaultDetails info type.
+// FollowerState is set only if a user was provided in the request and follows the vault.
+type VaultDetails struct {
+	Name                  string            `json:"name"`
+	VaultAddress          string            `json:"vaultAddress"`
+	Leader                string            `json:"leader"`
+	Description           string            `json:"description"`
+	Portfolio             Portfolio         `json:"portfolio"`
+	Apr                   float64           `json:"apr"`
+	FollowerState         *VaultFollower    `json:"followerState"`
+	LeaderFraction        float64           `json:"leaderFraction"`
+	LeaderCommission      float64           `json:"leaderCommission"`
+	Followers             []VaultFollower   `json:"followers"`
+	MaxDistributable      float64           `json:"maxDistributable"`
+	MaxWithdrawable       float64           `json:"maxWithdrawable"`
+	IsClosed              bool              `json:"isClosed"`
+	Relationship          VaultRelationship `json:"relationship"`
+	AllowDeposits         bool              `json:"allowDeposits"`
+	AlwaysCloseOnWithdraw bool              `json:"alwaysCloseOnWithdraw"`
+}
+
+// VaultEquity is the equity of a user in a vault.
+// Withdrawals are not possible before LockedUntilTimestamp.
+type VaultEquity struct {
+	VaultAddress         string  `json:"vaultAddress"`
+	Equity               float64 `json:"equity,string"`
+	LockedUntilTimestamp int64   `json:"lockedUntilTimestamp"`
+}
+
+// Delegation is the stake delegated by a user to a validator.
+type Delegation struct {
+	Validator            string  `json:"validator"`
+	Amount               float64 `json:"amount,string"`
+	LockedUntilTimestamp int64   `json:"lockedUntilTimestamp"`
+}
+
+// DelegatorSummary is the staking summary of a user.
+type DelegatorSummary struct {
+	Delegated              float64 `json:"delegated,string"`
+	Undelegated            float64 `json:"undelegated,string"`
+	TotalPendingWithdrawal float64 `json:"totalPendingWithdrawal,string"`
+	NPendingWithdrawals    int     `json:"nPendingWithdrawals"`
+}
+
+type DelegateDelta struct {
+	Validator    string  `json:"validator"`
+	Amount       float64 `json:"amount,string"`
+	IsUndelegate bool    `json:"isUndelegate"`
+}
+
+type CDepositDelta struct {
+	Amount float64 `json:"amount,string"`
+}
+
+type CWithdrawalDelta struct {
+	Amount float64 `json:"amount,string"`
+	Phase  string  `json:"phase"`
+}
+
+// Depending on the type of the staking event only one of the fields is non-nil
+type DelegatorDelta struct {
+	Delegate   *DelegateDelta    `json:"delegate,omitempty"`
+	CDeposit   *CDepositDelta    `json:"cDeposit,omitempty"`
+	Withdrawal *CWithdrawalDelta `json:"withdrawal,omitempty"`
+}
+
+// DelegatorHistoryEntry is a staking event of a user.
+type DelegatorHistoryEntry struct {
+	Time  int64          `json:"time"`
+	Hash  string         `json:"hash"`
+	Delta DelegatorDelta `json:"delta"`
+}
+
+// DelegatorReward is a staking reward of a user.
+// Source is "delegation" or "commission".
+type DelegatorReward struct {
+	Time        int64   `json:"time"`
+	Source      string  `json:"source"`
+	TotalAmount float64 `json:"totalAmount,string"`
+}
+
+// DailyUserVolume is the traded volume of a user and of the whole exchange on a given date.
+type DailyUserVolume struct {
+	Date      string  `json:"date"`
+	UserCross float64 `json:"userCross,string"`
+	UserAdd   float64 `json:"userAdd,string"`
+	Exchange  float64 `json:"exchange,string"`
+}
+
+// FeeTier is a VIP tier reached above NtlCutoff of 14 days volume.
+type FeeTier struct {
+	NtlCutoff float64 `json:"ntlCutoff,string"`
+	Cross     float64 `json:"cross,string"`
+	Add       float64 `json:"add,string"`
+	SpotCross float64 `json:"spotCross,string"`
+	SpotAdd   float64 `json:"spotAdd,string"`
+}
+
+// MakerRebateTier is a market maker tier reached above MakerFractionCutoff of the maker volume share.
+type MakerRebateTier struct {
+	MakerFractionCutoff float64 `json:"makerFractionCutoff,string"`
+	Add                 float64 `json:"add,string"`
+}
+
+type StakingDiscountTier struct {
+	BpsOfMaxSupply float64 `json:"bpsOfMaxSupply,string"`
+	Discount       float64 `json:"discount,string"`
+}
+
+type FeeSchedule struct {
+	Cross     float64 `json:"cross,string"`
+	Add       float64 `json:"add,string"`
+	SpotCross float64 `json:"spotCross,string"`
+	SpotAdd   float64 `json:"spotAdd,string"`
+	Tiers     struct {
+		Vip []FeeTier         `json:"vip"`
+		Mm  []MakerRebateTier `json:"mm"`
+	} `json:"tiers"`
+	ReferralDiscount     float64               `json:"referralDiscount,string"`
+	StakingDiscountTiers []StakingDiscountTier `json:"stakingDiscountTiers"`
+}
+
+// UserFees is the fee schedule and the effective fee rates of a user.
+// Cross rates apply to orders taking liquidity and add rates to orders adding liquidity.
+type UserFees struct {
+	DailyUserVlm           []DailyUserVolume    `json:"dailyUserVlm"`
+	FeeSchedule            FeeSchedule          `json:"feeSchedule"`
+	UserCrossRate          float64              `json:"userCrossRate,string"`
+	UserAddRate            float64              `json:"userAddRate,string"`
+	UserSpotCrossRate      float64              `json:"userSpotCrossRate,string"`
+	UserSpotAddRate        float64              `json:"userSpotAddRate,string"`
+	ActiveReferralDiscount float64              `json:"activeReferralDiscount,string"`
+	ActiveStakingDiscount  *StakingDiscountTier `json:"activeStakingDiscount"`
+}
+
+// FeeRate returns the effective fee rate of the user.
+// isTaker is true for orders taking liquidity and false for orders adding liquidity.
+// A negative rate is a maker rebate.
+func (f *UserFees) FeeRate(isSpot bool, isTaker bool) float64 {
+	switch {
+	case isSpot && isTaker:
+		return f.UserSpotCrossRate
+	case isSpot:
+		return f.UserSpotAddRate
+	case isTaker:
+		return f.UserCrossRate
+	default:
+		return f.UserAddRate
+	}
+}
+
+// EstimateOrderFee returns the expected fee of the order in quote currency,
+// computed as the order notional (Sz * LimitPx) times the effective fee rate.
+// For orders taking liquidity the fill price can be better than LimitPx, so the estimate is an upper bound.
+//
+//	fees.EstimateOrderFee(order, false, order.OrderType.Limit.Tif != TifAlo)
+func (f *UserFees) EstimateOrderFee(request OrderRequest, isSpot bool, isTaker bool) float64 {
+	return request.Sz * request.LimitPx * f.FeeRate(isSpot, isTaker)
+}
+
+// Volume returns the user volume (cross and add) over the last given days of DailyUserVlm.
+// Fee tiers are based on the 14 days volume.
+func (f *UserFees) Volume(days int) float64 {
+	volume := 0.0
+	for i := max(0, len(f.DailyUserVlm)-days); i < len(f.DailyUserVlm); i++ {
+		volume += f.DailyUserVlm[i].UserCross + f.DailyUserVlm[i].UserAdd
+	}
+	return volume
+}
+
+type ReferredBy struct {
+	Referrer string `json:"referrer"`
+	Code     string `json:"code"`
+}
+
+// ReferralUserState is the state of a user referred by the referrer.
+type ReferralUserState struct {
+	User                         string  `json:"user"`
+	TimeJoined                   int64   `json:"timeJoined"`
+	CumVlm                       float64 `json:"cumVlm,string"`
+	CumRewardedFeesSinceReferred float64 `json:"cumRewardedFeesSinceReferred,string"`
+	CumFeesRewardedToReferrer    float64 `json:"cumFeesRewardedToReferrer,string"`
+}
+
+// Referrer stages
+const (
+	ReferrerStageReady            = "ready"
+	ReferrerStageNeedToCreateCode = "needToCreateCode"
+	ReferrerStageNeedToTrade      = "needToTrade"
+)
+
+// ReferrerState is the referrer side of the referral program.
+// Data.Code and Data.ReferralStates are set in the ReferrerStageReady stage,
+// Data.Required is the volume still required in the ReferrerStageNeedToTrade stage.
+type ReferrerState struct {
+	Stage string `json:"stage"`
+	Data  struct {
+		Code           string              `json:"code"`
+		ReferralStates []ReferralUserState `json:"referralStates"`
+		Required       float64             `json:"required,string"`
+	} `json:"data"`
+}
+
+// ReferralReward is an entry of the referral reward history.
+// Earned is the reward earned for Vlm, the user volume, and ReferralVlm, the volume of the referred users.
+type ReferralReward struct {
+	Earned      float64 `json:"earned,string"`
+	Vlm         float64 `json:"vlm,string"`
+	ReferralVlm float64 `json:"referralVlm,string"`
+	Time        int64   `json:"time"`
+}
+
+// ReferralState is the response of the referral info type.
+type ReferralState struct {
+	ReferredBy       *ReferredBy      `json:"referredBy"`
+	CumVlm           float64          `json:"cumVlm,string"`
+	UnclaimedRewards float64          `json:"unclaimedRewards,string"`
+	ClaimedRewards   float64          `json:"claimedRewards,string"`
+	BuilderRewards   float64          `json:"builderRewards,string"`
+	ReferrerState    ReferrerState    `json:"referrerState"`
+	RewardHistory    []ReferralReward `json:"rewardHistory"`
+}
+
+// ActiveAssetData is the leverage and the tradable sizes of a user for a perpetual coin.
+// MaxTradeSzs and AvailableToTrade are [buy, sell].
+type ActiveAssetData struct {
+	User             string       `json:"user"`
+	Coin             string       `json:"coin"`
+	Leverage         Leverage     `json:"leverage"`
+	MaxTradeSzs      FloatStrings `json:"maxTradeSzs"`
+	AvailableToTrade FloatStrings `json:"availableToTrade"`
+	MarkPx           float64      `json:"markPx,string"`
+}
+
+// MaxTradeSz returns the maximum order size on the given side.
+func (d *ActiveAssetData) MaxTradeSz(isBuy bool) float64 {
+	return sideValue(d.MaxTradeSzs, isBuy)
+}
+
+// AvailableToTradeSz returns the margin available to trade on the given side.
+func (d *ActiveAssetData) AvailableToTradeSz(isBuy bool) float64 {
+	return sideValue(d.AvailableToTrade, isBuy)
+}
+
+func sideValue(values FloatStrings, isBuy bool) float64 {
+	index := 1
+	if isBuy {
+		index = 0
+	}
+	if len(values) <= index {
+		return 0
+	}
+	return values[index]
+}
@@ -0,0 +1,224 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestInfoTypes_MetaAndAssetCtxsResponse(t *testing.T) {
+	data := `[
+		{"universe": [
+			{"name": "BTC", "szDecimals": 5, "maxLeverage": 50},
+			{"name": "ETH", "szDecimals": 4, "maxLeverage": 50, "onlyIsolated": false}
+		]},
+		[
+			{"dayNtlVlm": "1169046.29406", "funding": "0.0000125", "impactPxs": ["14.3047", "14.3444"], "markPx": "14.3161", "midPx": "14.314", "openInterest": "688.11", "oraclePx": "14.32", "premium": "0.00031774", "prevDayPx": "15.322"},
+			{"dayNtlVlm": "0.0", "funding": "-0.00001", "impactPxs": null, "markPx": "2500.1", "midPx": null, "openInterest": "10.5", "oraclePx": "2500.0", "premium": null, "prevDayPx": "2400.0"}
+		]
+	]`
+	var response MetaAndAssetCtxsResponse
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	assets := response.Zip()
+	if len(assets) != 2 {
+		t.Fatalf("Zip() len = %v, want %v", len(assets), 2)
+	}
+	btc := assets[0]
+	if btc.Name != "BTC" || btc.AssetId != 0 || btc.SzDecimals != 5 {
+		t.Errorf("assets[0] = %+v, want BTC asset", btc)
+	}
+	if btc.Funding != 0.0000125 || btc.OpenInterest != 688.11 || btc.MarkPx != 14.3161 {
+		t.Errorf("assets[0].Context = %+v", btc.Context)
+	}
+	if btc.ImpactBidPx() != 14.3047 || btc.ImpactAskPx() != 14.3444 {
+		t.Errorf("assets[0].ImpactPxs = %v", btc.ImpactPxs)
+	}
+	eth := assets[1]
+	if eth.Name != "ETH" || eth.AssetId != 1 {
+		t.Errorf("assets[1] = %+v, want ETH asset", eth)
+	}
+	if eth.MidPx != 0 || eth.Premium != 0 || eth.ImpactBidPx() != 0 {
+		t.Errorf("assets[1].Context = %+v, want empty nullable fields", eth.Context)
+	}
+	if eth.Funding != -0.00001 {
+		t.Errorf("assets[1].Funding = %v, want %v", eth.Funding, -0.00001)
+	}
+}
+
+func TestInfoTypes_SpotMetaAndAssetCtxsResponse(t *testing.T) {
+	data := `[
+		{"universe": [{"tokens": [1, 0], "name": "PURR/USDC", "index": 0, "isCanonical": true}],
+		 "tokens": [{"name": "USDC", "szDecimals": 8, "weiDecimals": 8, "index": 0}, {"name": "PURR", "szDecimals": 0, "weiDecimals": 5, "index": 1}]},
+		[{"dayNtlVlm": "8906.0", "markPx": "0.14", "midPx": "0.209265", "prevDayPx": "0.20432", "coin": "PURR/USDC"}]
+	]`
+	var response SpotMetaAndAssetCtxsResponse
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(response.Meta.Tokens) != 2 || response.Meta.Universe[0].Name != "PURR/USDC" {
+		t.Errorf("response.Meta = %+v", response.Meta)
+	}
+	if len(response.AssetCtxs) != 1 || response.AssetCtxs[0].MidPx != "0.209265" {
+		t.Errorf("response.AssetCtxs = %+v", response.AssetCtxs)
+	}
+	if err := json.Unmarshal([]byte(`[{}]`), &response); err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want error for a single element array")
+	}
+}
+
+func TestInfoTypes_VaultDetails(t *testing.T) {
+	data := `{
+		"name": "Test",
+		"vaultAddress": "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303",
+		"leader": "0x677d831aef5328190852e24f13c46cac05f984e7",
+		"description": "This community-owned vault provides liquidity to Hyperliquid",
+		"portfolio": [
+			["day", {"accountValueHistory": [[1734397526634, "329265410.90790099"]], "pnlHistory": [[1734397526634, "0.0"], [1734401126634, "-8162.2"]], "vlm": "0.0"}],
+			["allTime", {"accountValueHistory": [], "pnlHistory": [], "vlm": "12.5"}]
+		],
+		"apr": 0.36387129259090006,
+		"followerState": null,
+		"leaderFraction": 0.0007904828725729887,
+		"leaderCommission": 0,
+		"followers": [{"user": "0x005844b2ffb2e122cf4244be7dbcb4f84924907c", "vaultEquity": "714491.71026243", "pnl": "3203.13502", "allTimePnl": "79843.61", "daysFollowing": 388, "vaultEntryTime": 1700926145201, "lockupUntil": 1734824439201}],
+		"maxDistributable": 94856.90,
+		"maxWithdrawable": 742.49,
+		"isClosed": false,
+		"relationship": {"type": "parent", "data": {"childAddresses": ["0x010461c14e146ac35fe42271bdc1134ee31c703a"]}},
+		"allowDeposits": true,
+		"alwaysCloseOnWithdraw": false
+	}`
+	var res VaultDetails
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	day, ok := res.Portfolio["day"]
+	if !ok {
+		t.Fatalf("Portfolio[day] not found in %+v", res.Portfolio)
+	}
+	if len(day.PnlHistory) != 2 || day.PnlHistory[1].Time != 1734401126634 || day.PnlHistory[1].Value != -8162.2 {
+		t.Errorf("Portfolio[day].PnlHistory = %+v", day.PnlHistory)
+	}
+	if pnl := day.Pnl(); pnl != -8162.2 {
+		t.Errorf("Portfolio[day].Pnl() = %v, want %v", pnl, -8162.2)
+	}
+	if value := day.LatestAccountValue(); value != 329265410.90790099 {
+		t.Errorf("Portfolio[day].LatestAccountValue() = %v, want %v", value, 329265410.90790099)
+	}
+	allTime := res.Portfolio[PortfolioAllTime]
+	if allTime.Pnl() != 0 || allTime.LatestAccountValue() != 0 {
+		t.Errorf("Portfolio[allTime] = %+v, want empty history", allTime)
+	}
+	if res.Portfolio["allTime"].Vlm != 12.5 {
+		t.Errorf("Portfolio[allTime].Vlm = %v, want %v", res.Portfolio["allTime"].Vlm, 12.5)
+	}
+	if len(res.Followers) != 1 || res.Followers[0].VaultEquity != 714491.71026243 || res.Followers[0].LockupUntil != 1734824439201 {
+		t.Errorf("Followers = %+v", res.Followers)
+	}
+	if res.FollowerState != nil {
+		t.Errorf("FollowerState = %+v, want nil", res.FollowerState)
+	}
+	if res.Relationship.Type != "parent" || len(res.Relationship.Data.ChildAddresses) != 1 {
+		t.Errorf("Relationship = %+v", res.Relationship)
+	}
+}
+
+func TestInfoTypes_UserFees(t *testing.T) {
+	data := `{
+		"dailyUserVlm": [
+			{"date": "2025-05-23", "userCross": "100.0", "userAdd": "50.0", "exchange": "2852367.0770729999"},
+			{"date": "2025-05-24", "userCross": "200.0", "userAdd": "0.0", "exchange": "2852367.0770729999"}
+		],
+		"feeSchedule": {
+			"cross": "0.00045", "add": "0.00015", "spotCross": "0.0007", "spotAdd": "0.0004",
+			"tiers": {"vip": [{"ntlCutoff": "5000000.0", "cross": "0.0004", "add": "0.00012", "spotCross": "0.0006", "spotAdd": "0.0003"}], "mm": [{"makerFractionCutoff": "0.005", "add": "-0.00001"}]},
+			"referralDiscount": "0.04",
+			"stakingDiscountTiers": [{"bpsOfMaxSupply": "0.0", "discount": "0.0"}, {"bpsOfMaxSupply": "0.0001", "discount": "0.05"}]
+		},
+		"userCrossRate": "0.000315",
+		"userAddRate": "0.000105",
+		"userSpotCrossRate": "0.00049",
+		"userSpotAddRate": "0.00028",
+		"activeReferralDiscount": "0.0",
+		"activeStakingDiscount": {"bpsOfMaxSupply": "4.7577998927", "discount": "0.3"}
+	}`
+	var fees UserFees
+	if err := json.Unmarshal([]byte(data), &fees); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if fees.FeeSchedule.Tiers.Vip[0].NtlCutoff != 5000000 || fees.FeeSchedule.Tiers.Mm[0].Add != -0.00001 {
+		t.Errorf("FeeSchedule.Tiers = %+v", fees.FeeSchedule.Tiers)
+	}
+	if fees.ActiveStakingDiscount == nil || fees.ActiveStakingDiscount.Discount != 0.3 {
+		t.Errorf("ActiveStakingDiscount = %+v", fees.ActiveStakingDiscount)
+	}
+	if volume := fees.Volume(14); volume != 350 {
+		t.Errorf("Volume(14) = %v, want %v", volume, 350)
+	}
+	if volume := fees.Volume(1); volume != 200 {
+		t.Errorf("Volume(1) = %v, want %v", volume, 200)
+	}
+	order := OrderRequest{Coin: "ETH", IsBuy: true, Sz: 2, LimitPx: 2500}
+	testCases := []struct {
+		name     string
+		isSpot   bool
+		isTaker  bool
+		expected float64
+	}{
+		{"Perp taker", false, true, 1.575},
+		{"Perp maker", false, false, 0.525},
+		{"Spot taker", true, true, 2.45},
+		{"Spot maker", true, false, 1.4},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := fees.EstimateOrderFee(order, tc.isSpot, tc.isTaker)
+			if math.Abs(res-tc.expected) > 1e-9 {
+				t.Errorf("EstimateOrderFee() = %v, want %v", res, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInfoTypes_ActiveAssetData(t *testing.T) {
+	data := `{"user": "0xb65822a30bbaaa68942d6f4c43d78704faeabbbb", "coin": "APT", "leverage": {"type": "isolated", "value": 3, "rawUsd": "-12.5"}, "maxTradeSzs": ["24836370.44", "1000.5"], "availableToTrade": ["37019438.02", "37019000.0"], "markPx": "4.4716"}`
+	var res ActiveAssetData
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if res.MaxTradeSz(true) != 24836370.44 || res.MaxTradeSz(false) != 1000.5 {
+		t.Errorf("MaxTradeSzs = %v", res.MaxTradeSzs)
+	}
+	if res.AvailableToTradeSz(true) != 37019438.02 || res.AvailableToTradeSz(false) != 37019000 {
+		t.Errorf("AvailableToTrade = %v", res.AvailableToTrade)
+	}
+	if res.Leverage.Value != 3 || res.Leverage.RawUsd != -12.5 || res.MarkPx != 4.4716 {
+		t.Errorf("ActiveAssetData = %+v", res)
+	}
+	empty := ActiveAssetData{}
+	if empty.MaxTradeSz(true) != 0 {
+		t.Errorf("MaxTradeSz() = %v, want 0 for missing data", empty.MaxTradeSz(true))
+	}
+}
+
+func TestInfoTypes_ReferralState(t *testing.T) {
+	data := `{"referredBy": {"referrer": "0x5ac99df645f3414876c816caa18b2d234024b487", "code": "TESTNET"}, "cumVlm": "149428030.6628420055", "unclaimedRewards": "11.047361", "claimedRewards": "22.743781", "builderRewards": "0.027802",
+		"referrerState": {"stage": "ready", "data": {"code": "TEST", "referralStates": [{"cumVlm": "790.43", "cumRewardedFeesSinceReferred": "0.1582", "cumFeesRewardedToReferrer": "0.0632", "timeJoined": 1679425029416, "user": "0x11af2b93dcb3568b7bf2b2a6b8e1b3e1b9a2d001"}]}},
+		"rewardHistory": [{"earned": "1.86421", "vlm": "21495.9", "referralVlm": "3105.2", "time": 1697673600000}]}`
+	var res ReferralState
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if res.ReferredBy == nil || res.ReferredBy.Code != "TESTNET" || res.UnclaimedRewards != 11.047361 {
+		t.Errorf("ReferralState = %+v", res)
+	}
+	if len(res.ReferrerState.Data.ReferralStates) != 1 || res.ReferrerState.Data.ReferralStates[0].CumVlm != 790.43 {
+		t.Errorf("ReferrerState = %+v", res.ReferrerState)
+	}
+	want := ReferralReward{Earned: 1.86421, Vlm: 21495.9, ReferralVlm: 3105.2, Time: 1697673600000}
+	if len(res.RewardHistory) != 1 || res.RewardHistory[0] != want {
+		t.Errorf("RewardHistory = %+v, want [%+v]", res.RewardHistory, want)
+	}
+}
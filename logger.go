@@ -0,0 +1,21 @@
+package hyperliquid
+
+import (
+	"log/slog"
+)
+
+// Logger is the logging interface of the clients.
+// Messages come with key/value pairs like log/slog: Debug("Request", "endpoint", "info", "status", 200).
+// *slog.Logger implements it, the logrus subpackage adapts a logrus logger.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// NewSlogLogger returns a Logger writing to a slog logger, slog.Default() if nil.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
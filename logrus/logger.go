@@ -0,0 +1,53 @@
+// Package logrus adapts a logrus logger to the Logger interface of the Hyperliquid clients,
+// so the root package does not depend on logrus.
+//
+//	client := hyperliquid.NewHyperliquid(config, logrus.WithLogger(logger))
+package logrus
+
+import (
+	"fmt"
+
+	hyperliquid "github.com/chainswatch/go-hyperliquid"
+	sirupsen "github.com/sirupsen/logrus"
+)
+
+// Logger adapts a logrus logger to hyperliquid.Logger.
+// The key/value pairs of the messages become logrus fields.
+type Logger struct {
+	*sirupsen.Logger
+}
+
+// NewLogger returns a hyperliquid.Logger writing to a logrus logger.
+func NewLogger(logger *sirupsen.Logger) *Logger {
+	return &Logger{Logger: logger}
+}
+
+// WithLogger sets a logrus logger as the logger of the clients, slog.Default() if nil.
+func WithLogger(logger *sirupsen.Logger) hyperliquid.ClientOption {
+	if logger == nil {
+		return hyperliquid.WithLogger(nil)
+	}
+	return hyperliquid.WithLogger(NewLogger(logger))
+}
+
+func (l *Logger) Debug(msg string, args ...any) {
+	l.WithFields(fields(args)).Debug(msg)
+}
+
+func (l *Logger) Error(msg string, args ...any) {
+	l.WithFields(fields(args)).Error(msg)
+}
+
+// fields converts slog style key/value pairs to logrus fields.
+// A value without key is stored under !BADKEY like slog does.
+func fields(args []any) sirupsen.Fields {
+	fields := make(sirupsen.Fields, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			fields["!BADKEY"] = args[i]
+			break
+		}
+		fields[fmt.Sprint(args[i])] = args[i+1]
+	}
+	return fields
+}
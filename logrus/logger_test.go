@@ -0,0 +1,45 @@
+package logrus
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	hyperliquid "github.com/chainswatch/go-hyperliquid"
+	sirupsen "github.com/sirupsen/logrus"
+)
+
+func TestLogger_Fields(t *testing.T) {
+	var out bytes.Buffer
+	logger := sirupsen.New()
+	logger.SetOutput(&out)
+	logger.SetLevel(sirupsen.DebugLevel)
+	logger.SetFormatter(&sirupsen.JSONFormatter{})
+
+	NewLogger(logger).Debug("Request", "endpoint", "exchange", "status", 200, "dangling")
+	got := out.String()
+	for _, field := range []string{`"msg":"Request"`, `"level":"debug"`, `"endpoint":"exchange"`, `"status":200`, `"!BADKEY":"dangling"`} {
+		if !strings.Contains(got, field) {
+			t.Errorf("Debug() = %s, want field %s", got, field)
+		}
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := sirupsen.New()
+	logger.SetLevel(sirupsen.ErrorLevel)
+
+	hl := hyperliquid.NewHyperliquid(&hyperliquid.HyperliquidClientConfig{IsMainnet: false}, WithLogger(logger))
+	for name, clientLogger := range map[string]hyperliquid.Logger{"ExchangeAPI": hl.ExchangeAPI.Logger, "InfoAPI": hl.InfoAPI.Logger} {
+		adapter, ok := clientLogger.(*Logger)
+		if !ok || adapter.Logger != logger {
+			t.Errorf("%s.Logger = %v, want %p", name, clientLogger, logger)
+		}
+	}
+
+	// A nil logger keeps the default slog logger
+	if got := hyperliquid.NewClient(false, WithLogger(nil)).Logger; got != hyperliquid.Logger(slog.Default()) {
+		t.Errorf("WithLogger(nil) Logger = %v, want slog.Default()", got)
+	}
+}
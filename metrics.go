@@ -0,0 +1,181 @@
+package hyperliquid
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Order outcomes recorded by Metrics.AddOrders
+const (
+	ORDER_PLACED   = "placed"   // accepted by the exchange, resting or filled
+	ORDER_FILLED   = "filled"   // filled on placement
+	ORDER_REJECTED = "rejected" // rejected with an error status
+)
+
+// Kinds of the errors returned by the exchange, recorded by Metrics.IncExchangeError
+const (
+	EXCHANGE_ERROR_RATE_LIMITED        = "rate_limited"
+	EXCHANGE_ERROR_INSUFFICIENT_MARGIN = "insufficient_margin"
+	EXCHANGE_ERROR_MIN_NOTIONAL        = "min_notional"
+	EXCHANGE_ERROR_INVALID_PRICE       = "invalid_price"
+	EXCHANGE_ERROR_POST_ONLY           = "post_only_rejected"
+	EXCHANGE_ERROR_IOC_UNMATCHED       = "ioc_unmatched"
+	EXCHANGE_ERROR_REDUCE_ONLY         = "reduce_only_rejected"
+	EXCHANGE_ERROR_UNKNOWN_SIGNER      = "unknown_signer"
+	EXCHANGE_ERROR_INVALID_NONCE       = "invalid_nonce"
+	EXCHANGE_ERROR_HTTP                = "http"
+	EXCHANGE_ERROR_OTHER               = "other"
+)
+
+// Metrics receives the measurements of the requests sent by the clients.
+// Implementations must be safe for concurrent use. See the prometheus subpackage for a Prometheus collector.
+type Metrics interface {
+	// ObserveRequest records a request to endpoint ("info" or "exchange").
+	// requestType is the info type or the action type, status the HTTP status or 0 when no response was received.
+	ObserveRequest(endpoint string, requestType string, status int, latency time.Duration)
+	// IncExchangeError records an error returned by the exchange for an action, kind is one of the EXCHANGE_ERROR_* values.
+	IncExchangeError(action string, kind string)
+	// AddOrders records count orders with an outcome, one of the ORDER_* values.
+	AddOrders(outcome string, count int)
+	// SetRateLimit records the last rate limit usage seen for a user.
+	SetRateLimit(user string, limits RatesLimits)
+}
+
+// NoopMetrics discards all measurements, it is the default Metrics of the clients.
+type NoopMetrics struct{}
+
+func (NoopMetrics) ObserveRequest(string, string, int, time.Duration) {}
+func (NoopMetrics) IncExchangeError(string, string)                   {}
+func (NoopMetrics) AddOrders(string, int)                             {}
+func (NoopMetrics) SetRateLimit(string, RatesLimits)                  {}
+
+// exchangeErrorKinds maps patterns of the lower case exchange error messages to their kind, first match wins.
+var exchangeErrorKinds = []struct {
+	pattern *regexp.Regexp
+	kind    string
+}{
+	{fragment("too many"), EXCHANGE_ERROR_RATE_LIMITED},
+	{fragment("rate limit"), EXCHANGE_ERROR_RATE_LIMITED},
+	{fragment("insufficient margin"), EXCHANGE_ERROR_INSUFFICIENT_MARGIN},
+	{fragment("minimum value"), EXCHANGE_ERROR_MIN_NOTIONAL},
+	{fragment("tick size"), EXCHANGE_ERROR_INVALID_PRICE},
+	{fragment("invalid price"), EXCHANGE_ERROR_INVALID_PRICE},
+	{fragment("post only"), EXCHANGE_ERROR_POST_ONLY},
+	{fragment("could not immediately match"), EXCHANGE_ERROR_IOC_UNMATCHED},
+	{fragment("reduce only"), EXCHANGE_ERROR_REDUCE_ONLY},
+	// "User or API Wallet 0x... does not exist.", the signer recovered from the signature is unknown
+	{regexp.MustCompile(`user or api wallet 0x[0-9a-f]+ does not exist`), EXCHANGE_ERROR_UNKNOWN_SIGNER},
+	{fragment("nonce"), EXCHANGE_ERROR_INVALID_NONCE},
+}
+
+// fragment returns a pattern matching text anywhere in a message.
+func fragment(text string) *regexp.Regexp {
+	return regexp.MustCompile(regexp.QuoteMeta(text))
+}
+
+// ClassifyExchangeError returns the kind of an error message returned by the exchange.
+func ClassifyExchangeError(message string) string {
+	message = strings.ToLower(message)
+	for _, entry := range exchangeErrorKinds {
+		if entry.pattern.MatchString(message) {
+			return entry.kind
+		}
+	}
+	return EXCHANGE_ERROR_OTHER
+}
+
+// recordMetrics records a request and what its response tells about orders, errors and rate limits.
+func (client *Client) recordMetrics(endpoint string, payload []byte, status int, latency time.Duration, body []byte) {
+	if _, ok := client.metrics.(NoopMetrics); ok || client.metrics == nil {
+		return
+	}
+	requestType, _ := requestSummary(payload)
+	client.metrics.ObserveRequest(endpoint, requestType, status, latency)
+	switch {
+	case status == 0:
+		return
+	case status >= http.StatusBadRequest:
+		if endpoint == "exchange" {
+			kind := EXCHANGE_ERROR_HTTP
+			if status == http.StatusTooManyRequests {
+				kind = EXCHANGE_ERROR_RATE_LIMITED
+			}
+			client.metrics.IncExchangeError(requestType, kind)
+		}
+	case endpoint == "exchange":
+		client.recordExchangeResponse(requestType, body)
+	case endpoint == "info" && requestType == "userRateLimit":
+		var request struct {
+			User string `json:"user"`
+		}
+		var limits RatesLimits
+		if json.Unmarshal(payload, &request) == nil && json.Unmarshal(body, &limits) == nil {
+			client.metrics.SetRateLimit(strings.ToLower(request.User), limits)
+		}
+	}
+}
+
+// recordExchangeResponse records the errors and order outcomes of an /exchange response.
+// Orders are counted from the responses of type "order", which are returned for order and batchModify
+// actions, sent directly or wrapped in a multiSig action.
+func (client *Client) recordExchangeResponse(action string, body []byte) {
+	var response struct {
+		Status   string          `json:"status"`
+		Response json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return
+	}
+	if response.Status == "err" {
+		var message string
+		_ = json.Unmarshal(response.Response, &message)
+		client.metrics.IncExchangeError(action, ClassifyExchangeError(message))
+		return
+	}
+	var inner struct {
+		Type string `json:"type"`
+		Data struct {
+			Statuses []json.RawMessage `json:"statuses"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(response.Response, &inner); err != nil {
+		return
+	}
+	var placed, filled, rejected int
+	for _, raw := range inner.Data.Statuses {
+		// Statuses are objects for orders and "success" strings for cancels
+		var status struct {
+			Resting json.RawMessage `json:"resting"`
+			Filled  json.RawMessage `json:"filled"`
+			Error   string          `json:"error"`
+		}
+		if json.Unmarshal(raw, &status) != nil {
+			continue
+		}
+		switch {
+		case status.Error != "":
+			rejected++
+			client.metrics.IncExchangeError(action, ClassifyExchangeError(status.Error))
+		case status.Filled != nil:
+			placed++
+			filled++
+		case status.Resting != nil:
+			placed++
+		}
+	}
+	if inner.Type != "order" {
+		return
+	}
+	if placed > 0 {
+		client.metrics.AddOrders(ORDER_PLACED, placed)
+	}
+	if filled > 0 {
+		client.metrics.AddOrders(ORDER_FILLED, filled)
+	}
+	if rejected > 0 {
+		client.metrics.AddOrders(ORDER_REJECTED, rejected)
+	}
+}
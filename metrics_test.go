@@ -0,0 +1,125 @@
+package hyperliquid
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics keeps the measurements it receives as strings.
+type recordingMetrics struct {
+	mu     sync.Mutex
+	events []string
+	limits map[string]RatesLimits
+}
+
+func (m *recordingMetrics) record(event string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *recordingMetrics) ObserveRequest(endpoint string, requestType string, status int, latency time.Duration) {
+	m.record("request " + endpoint + " " + requestType + " " + http.StatusText(status))
+}
+
+func (m *recordingMetrics) IncExchangeError(action string, kind string) {
+	m.record("error " + action + " " + kind)
+}
+
+func (m *recordingMetrics) AddOrders(outcome string, count int) {
+	for range count {
+		m.record("order " + outcome)
+	}
+}
+
+func (m *recordingMetrics) SetRateLimit(user string, limits RatesLimits) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.limits[user] = limits
+}
+
+func TestClassifyExchangeError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"Too many cumulative requests sent (1234 > 1000) for cumulative volume traded $0.", EXCHANGE_ERROR_RATE_LIMITED},
+		{"Insufficient margin to place order. asset=4", EXCHANGE_ERROR_INSUFFICIENT_MARGIN},
+		{"Order must have minimum value of $10. asset=4", EXCHANGE_ERROR_MIN_NOTIONAL},
+		{"Price must be divisible by tick size. asset=4", EXCHANGE_ERROR_INVALID_PRICE},
+		{"Post only order would have immediately matched, bbo was 1@2. asset=4", EXCHANGE_ERROR_POST_ONLY},
+		{"Order could not immediately match against any resting orders. asset=4", EXCHANGE_ERROR_IOC_UNMATCHED},
+		{"Reduce only order would increase position. asset=4", EXCHANGE_ERROR_REDUCE_ONLY},
+		{"User or API Wallet 0x14791697260e4c9a71f18484c9f997b308e59325 does not exist.", EXCHANGE_ERROR_UNKNOWN_SIGNER},
+		{"Vault does not exist.", EXCHANGE_ERROR_OTHER},
+		{"Sub-account does not exist", EXCHANGE_ERROR_OTHER},
+		{"Invalid nonce: duplicate nonce", EXCHANGE_ERROR_INVALID_NONCE},
+		{"Something else", EXCHANGE_ERROR_OTHER},
+	}
+	for _, tt := range tests {
+		if got := ClassifyExchangeError(tt.message); got != tt.want {
+			t.Errorf("ClassifyExchangeError(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestClient_RequestMetrics(t *testing.T) {
+	responses := map[string]string{
+		"order":          `{"status":"ok","response":{"type":"order","data":{"statuses":[{"resting":{"oid":1}},{"filled":{"oid":2,"totalSz":"1","avgPx":"10"}},{"error":"Insufficient margin to place order. asset=4"}]}}}`,
+		"cancel":         `{"status":"ok","response":{"type":"cancel","data":{"statuses":["success"]}}}`,
+		"batchModify":    `{"status":"ok","response":{"type":"order","data":{"statuses":[{"resting":{"oid":3}}]}}}`,
+		"multiSig":       `{"status":"ok","response":{"type":"order","data":{"statuses":[{"filled":{"oid":4,"totalSz":"1","avgPx":"10"}}]}}}`,
+		"updateLeverage": `{"status":"err","response":"Too many cumulative requests sent"}`,
+		"userRateLimit":  `{"cumVlm":"2854574.593578","nRequestsUsed":2890,"nRequestsCap":2864574}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		actionType, _ := requestSummary(payload)
+		w.Write([]byte(responses[actionType]))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{limits: map[string]RatesLimits{}}
+	client := NewClient(true, WithMetrics(metrics))
+	client.baseUrl = server.URL
+
+	for _, action := range []string{"order", "cancel", "batchModify", "multiSig", "updateLeverage"} {
+		request := ExchangeRequest{Action: map[string]any{"type": action}, Nonce: 1}
+		if _, err := client.Request("/exchange", request); err != nil {
+			t.Fatalf("Request(%s) error = %v", action, err)
+		}
+	}
+	if _, err := client.Request("/info", InfoRequest{User: "0xABC", Typez: "userRateLimit"}); err != nil {
+		t.Fatalf("Request(userRateLimit) error = %v", err)
+	}
+
+	want := []string{
+		"request exchange order OK",
+		"error order insufficient_margin",
+		"order placed",
+		"order placed",
+		"order filled",
+		"order rejected",
+		"request exchange cancel OK",
+		"request exchange batchModify OK",
+		"order placed",
+		"request exchange multiSig OK",
+		"order placed",
+		"order filled",
+		"request exchange updateLeverage OK",
+		"error updateLeverage rate_limited",
+		"request info userRateLimit OK",
+	}
+	if !slices.Equal(metrics.events, want) {
+		t.Errorf("metrics events = %q, want %q", metrics.events, want)
+	}
+	limits := metrics.limits["0xabc"]
+	if limits.NRequestsUsed != 2890 || limits.NRequestsCap != 2864574 || limits.CumVlm != 2854574.593578 {
+		t.Errorf("SetRateLimit() limits = %+v", metrics.limits)
+	}
+}
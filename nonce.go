@@ -0,0 +1,133 @@
+package hyperliquid
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Nonce window accepted by the exchange, relative to the current time
+const NONCE_MAX_AGE = 2 * 24 * time.Hour // Nonces older than 2 days are rejected
+const NONCE_MAX_AHEAD = 24 * time.Hour   // Nonces more than 1 day ahead are rejected
+
+// NonceManager hands out nonces for the actions signed by a signer.
+// Nonces of a signer must be unique and should be increasing, the exchange keeps
+// the 100 highest nonces of every signer and rejects any nonce below them.
+type NonceManager interface {
+	Next(signer common.Address) (uint64, error)
+}
+
+// defaultNonceManager is shared by every ExchangeAPI of the process,
+// so several clients signing with the same key do not collide.
+var defaultNonceManager = NewMemoryNonceManager()
+
+// ValidateNonce returns an error if the nonce is outside of the window accepted by the exchange.
+// Nonces are timestamps in milliseconds.
+func ValidateNonce(nonce uint64, now time.Time) error {
+	ts := time.UnixMilli(int64(nonce))
+	if ts.Before(now.Add(-NONCE_MAX_AGE)) {
+		return APIError{Message: fmt.Sprintf("Nonce %d is too old: %s is more than %s before now", nonce, ts.UTC().Format(time.RFC3339), NONCE_MAX_AGE)}
+	}
+	if ts.After(now.Add(NONCE_MAX_AHEAD)) {
+		return APIError{Message: fmt.Sprintf("Nonce %d is too far in the future: %s is more than %s after now", nonce, ts.UTC().Format(time.RFC3339), NONCE_MAX_AHEAD)}
+	}
+	return nil
+}
+
+// nextNonce returns the current time in milliseconds or last+1 if the time did not move past last.
+func nextNonce(last uint64, now time.Time) (uint64, error) {
+	nonce := max(uint64(now.UnixMilli()), last+1)
+	if err := ValidateNonce(nonce, now); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// MemoryNonceManager keeps the last nonce of every signer in memory.
+// Nonces restart from the current time when the process restarts.
+type MemoryNonceManager struct {
+	mu   sync.Mutex
+	last map[common.Address]uint64
+}
+
+// NewMemoryNonceManager returns an empty in-memory nonce manager.
+func NewMemoryNonceManager() *MemoryNonceManager {
+	return &MemoryNonceManager{last: make(map[common.Address]uint64)}
+}
+
+// Next returns the next nonce of the signer.
+func (m *MemoryNonceManager) Next(signer common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nonce, err := nextNonce(m.last[signer], time.Now())
+	if err != nil {
+		return 0, err
+	}
+	m.last[signer] = nonce
+	return nonce, nil
+}
+
+// FileNonceManager stores the last nonce of every signer in a file of dir.
+// The file is locked while a nonce is handed out, so several processes signing
+// with the same key can share the directory, and nonces survive restarts.
+type FileNonceManager struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileNonceManager returns a nonce manager storing its files in dir.
+// The directory is created if it does not exist.
+func NewFileNonceManager(dir string) (*FileNonceManager, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileNonceManager{dir: dir}, nil
+}
+
+// path returns the nonce file of the signer.
+func (m *FileNonceManager) path(signer common.Address) string {
+	return filepath.Join(m.dir, strings.ToLower(signer.Hex())+".nonce")
+}
+
+// Next returns the next nonce of the signer and persists it.
+func (m *FileNonceManager) Next(signer common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	path := m.path(signer)
+	unlock, err := lockFile(path)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	var last uint64
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if content := strings.TrimSpace(string(data)); content != "" {
+		last, err = strconv.ParseUint(content, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid nonce file %s: %w", path, err)
+		}
+	}
+	nonce, err := nextNonce(last, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	// Write to a temporary file first, a crash never leaves a truncated nonce behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(nonce, 10)), 0o600); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
@@ -0,0 +1,37 @@
+//go:build !unix
+
+package hyperliquid
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const nonceLockTimeout = 10 * time.Second // Give up waiting for the lock after this long
+const nonceLockStale = 30 * time.Second   // A lock older than this was left by a dead process
+
+// lockFile creates path.lock exclusively and returns the function removing it.
+// A lock left behind by a dead process is removed once it is stale.
+func lockFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(nonceLockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > nonceLockStale {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, APIError{Message: fmt.Sprintf("Timeout waiting for nonce lock %s", lockPath)}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package hyperliquid
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on path.lock and returns the function releasing it.
+// The lock is released by the kernel if the process dies.
+func lockFile(path string) (func(), error) {
+	file, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return func() {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}, nil
+}
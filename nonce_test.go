@@ -0,0 +1,131 @@
+package hyperliquid
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testSigner      = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	testOtherSigner = common.HexToAddress("0x0000000000000000000000000000000000000002")
+)
+
+func TestNonce_ValidateNonce(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		nonce   time.Time
+		wantErr bool
+	}{
+		{name: "Now", nonce: now, wantErr: false},
+		{name: "One day old", nonce: now.Add(-24 * time.Hour), wantErr: false},
+		{name: "Three days old", nonce: now.Add(-72 * time.Hour), wantErr: true},
+		{name: "Two days ahead", nonce: now.Add(48 * time.Hour), wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateNonce(uint64(tc.nonce.UnixMilli()), now)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateNonce() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// collectNonces requests n nonces from every manager concurrently and fails on duplicates.
+func collectNonces(t *testing.T, managers []NonceManager, n int) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[uint64]bool)
+	for _, manager := range managers {
+		wg.Add(1)
+		go func(manager NonceManager) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				nonce, err := manager.Next(testSigner)
+				if err != nil {
+					t.Errorf("Next() error = %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[nonce] {
+					t.Errorf("Next() returned duplicate nonce %d", nonce)
+				}
+				seen[nonce] = true
+				mu.Unlock()
+			}
+		}(manager)
+	}
+	wg.Wait()
+}
+
+func TestNonce_MemoryNonceManager(t *testing.T) {
+	manager := NewMemoryNonceManager()
+	first, err := manager.Next(testSigner)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	second, _ := manager.Next(testSigner)
+	if second <= first {
+		t.Errorf("Next() = %d, want > %d", second, first)
+	}
+	// Push the counter of the signer an hour ahead, the other signer must still start from the current time
+	ahead := uint64(time.Now().Add(time.Hour).UnixMilli())
+	manager.last[testSigner] = ahead
+	before := uint64(time.Now().UnixMilli())
+	other, _ := manager.Next(testOtherSigner)
+	after := uint64(time.Now().UnixMilli())
+	if other < before || other > after {
+		t.Errorf("Next() of another signer = %d, want between %d and %d", other, before, after)
+	}
+	if next, _ := manager.Next(testSigner); next != ahead+1 {
+		t.Errorf("Next() = %d, want %d", next, ahead+1)
+	}
+	collectNonces(t, []NonceManager{manager, manager, manager}, 200)
+}
+
+func TestNonce_FileNonceManager(t *testing.T) {
+	dir := t.TempDir()
+	manager, err := NewFileNonceManager(dir)
+	if err != nil {
+		t.Fatalf("NewFileNonceManager() error = %v", err)
+	}
+	first, err := manager.Next(testSigner)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	// A new manager on the same directory continues after the persisted nonce
+	restarted, _ := NewFileNonceManager(dir)
+	second, err := restarted.Next(testSigner)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if second <= first {
+		t.Errorf("Next() after restart = %d, want > %d", second, first)
+	}
+	// Managers sharing the directory behave like processes sharing a key
+	collectNonces(t, []NonceManager{manager, restarted}, 100)
+}
+
+func TestNonce_FileNonceManagerOutOfWindow(t *testing.T) {
+	dir := t.TempDir()
+	manager, _ := NewFileNonceManager(dir)
+	ahead := time.Now().Add(48 * time.Hour).UnixMilli()
+	if err := os.WriteFile(manager.path(testSigner), []byte(strconv.FormatInt(ahead, 10)), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if _, err := manager.Next(testSigner); err == nil {
+		t.Errorf("Next() error = nil, want nonce out of window")
+	}
+	if err := os.WriteFile(manager.path(testSigner), []byte("invalid"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if _, err := manager.Next(testSigner); err == nil {
+		t.Errorf("Next() error = nil, want invalid nonce file")
+	}
+}
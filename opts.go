@@ -1,10 +1,8 @@
 package hyperliquid
 
 import (
+	"log/slog"
 	"net/http"
-	"os"
-
-	log "github.com/sirupsen/logrus"
 )
 
 // ClientOption defines a function type for configuring the Hyperliquid client
@@ -13,8 +11,12 @@ type ClientOption func(*clientOptions)
 // clientOptions holds all configurable options for the Hyperliquid client
 type clientOptions struct {
 	httpClient *http.Client
-	logger     *log.Logger
+	logger     Logger
 	debug      bool
+
+	redactAddresses bool
+	debugBodyLimit  int
+	metrics         Metrics
 }
 
 // WithHTTPClient sets a custom HTTP client
@@ -24,34 +26,76 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
-// WithLogger sets a custom logger
-func WithLogger(logger *log.Logger) ClientOption {
+// WithLogger sets any implementation of the Logger interface, slog.Default() if nil.
+// See WithSlogLogger for log/slog and the logrus subpackage for logrus.
+func WithLogger(logger Logger) ClientOption {
+	return func(opts *clientOptions) {
+		switch l := logger.(type) {
+		case nil:
+			opts.logger = slog.Default()
+		case *slog.Logger:
+			opts.logger = NewSlogLogger(l) // a nil *slog.Logger too
+		default:
+			opts.logger = logger
+		}
+	}
+}
+
+// WithSlogLogger sets a custom slog logger, slog.Default() if nil
+func WithSlogLogger(logger *slog.Logger) ClientOption {
 	return func(opts *clientOptions) {
-		opts.logger = logger
+		opts.logger = NewSlogLogger(logger)
 	}
 }
 
+// WithCustomLogger sets any implementation of the Logger interface, slog.Default() if nil.
+// It is the same as WithLogger.
+func WithCustomLogger(logger Logger) ClientOption {
+	return WithLogger(logger)
+}
+
 // WithDebug sets the debug mode
+// Debug messages go to the logger at debug level, the default slog.Default() drops them unless its handler enables that level.
 func WithDebug(debug bool) ClientOption {
 	return func(opts *clientOptions) {
 		opts.debug = debug
 	}
 }
 
+// WithRedactAddresses masks the addresses in the debug logs (0x1234…abcd).
+// Signatures and private keys are always redacted.
+func WithRedactAddresses(redact bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.redactAddresses = redact
+	}
+}
+
+// WithDebugBodyLimit sets the max size in bytes of the request and response bodies in the debug logs.
+// The default is DEFAULT_DEBUG_BODY_LIMIT, a limit <= 0 logs the full bodies.
+func WithDebugBodyLimit(limit int) ClientOption {
+	return func(opts *clientOptions) {
+		opts.debugBodyLimit = limit
+	}
+}
+
+// WithMetrics sets the Metrics recording the requests, orders and rate limits of the client.
+// The default discards them.
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(opts *clientOptions) {
+		opts.metrics = metrics
+	}
+}
+
 // getDefaultOptions returns the default client options
 func getDefaultOptions() *clientOptions {
-	logger := log.New()
-	logger.SetFormatter(&log.TextFormatter{
-		FullTimestamp: true,
-		PadLevelText:  true,
-	})
-	logger.SetOutput(os.Stdout)
-	logger.SetLevel(log.DebugLevel)
-
 	return &clientOptions{
 		httpClient: http.DefaultClient,
-		logger:     logger,
-		debug:      false,
+		// The level of the messages is left to the handler of the application
+		logger: slog.Default(),
+		debug:  false,
+
+		debugBodyLimit: DEFAULT_DEBUG_BODY_LIMIT,
+		metrics:        NoopMetrics{},
 	}
 }
 
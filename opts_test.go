@@ -1,13 +1,19 @@
 package hyperliquid
 
 import (
+	"context"
+	"io"
+	"log/slog"
 	"net/http"
 	"testing"
 	"time"
-
-	log "github.com/sirupsen/logrus"
 )
 
+// newLevelLogger returns a slog logger discarding its messages and enabled from level.
+func newLevelLogger(level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: level}))
+}
+
 func TestClientOptions_WithHTTPClient(t *testing.T) {
 	customClient := &http.Client{
 		Timeout: 30 * time.Second,
@@ -33,8 +39,7 @@ func TestClientOptions_WithHTTPClient(t *testing.T) {
 }
 
 func TestClientOptions_WithLogger(t *testing.T) {
-	customLogger := log.New()
-	customLogger.SetLevel(log.ErrorLevel)
+	customLogger := newLevelLogger(slog.LevelError)
 
 	hl := NewHyperliquid(&HyperliquidClientConfig{
 		IsMainnet:      false,
@@ -42,16 +47,16 @@ func TestClientOptions_WithLogger(t *testing.T) {
 		AccountAddress: "",
 	}, WithLogger(customLogger))
 
-	if hl.ExchangeAPI.Logger != customLogger {
+	if hl.ExchangeAPI.Logger != Logger(customLogger) {
 		t.Errorf("ExchangeAPI.Logger = %p, want %p", hl.ExchangeAPI.Logger, customLogger)
 	}
 
-	if hl.InfoAPI.Logger != customLogger {
+	if hl.InfoAPI.Logger != Logger(customLogger) {
 		t.Errorf("InfoAPI.Logger = %p, want %p", hl.InfoAPI.Logger, customLogger)
 	}
 
-	if hl.ExchangeAPI.Logger.Level != log.ErrorLevel {
-		t.Errorf("ExchangeAPI.Logger.Level = %v, want %v", hl.ExchangeAPI.Logger.Level, log.ErrorLevel)
+	if hl.ExchangeAPI.Logger.(*slog.Logger).Enabled(context.Background(), slog.LevelWarn) {
+		t.Errorf("ExchangeAPI.Logger is enabled at warn level, want error level")
 	}
 }
 
@@ -59,8 +64,7 @@ func TestClientOptions_MultipleOptions(t *testing.T) {
 	customClient := &http.Client{
 		Timeout: 45 * time.Second,
 	}
-	customLogger := log.New()
-	customLogger.SetLevel(log.WarnLevel)
+	customLogger := newLevelLogger(slog.LevelWarn)
 
 	hl := NewHyperliquid(&HyperliquidClientConfig{
 		IsMainnet:      true,
@@ -72,7 +76,7 @@ func TestClientOptions_MultipleOptions(t *testing.T) {
 		t.Errorf("ExchangeAPI.httpClient = %p, want %p", hl.ExchangeAPI.httpClient, customClient)
 	}
 
-	if hl.ExchangeAPI.Logger != customLogger {
+	if hl.ExchangeAPI.Logger != Logger(customLogger) {
 		t.Errorf("ExchangeAPI.Logger = %p, want %p", hl.ExchangeAPI.Logger, customLogger)
 	}
 
@@ -80,7 +84,7 @@ func TestClientOptions_MultipleOptions(t *testing.T) {
 		t.Errorf("InfoAPI.httpClient = %p, want %p", hl.InfoAPI.httpClient, customClient)
 	}
 
-	if hl.InfoAPI.Logger != customLogger {
+	if hl.InfoAPI.Logger != Logger(customLogger) {
 		t.Errorf("InfoAPI.Logger = %p, want %p", hl.InfoAPI.Logger, customLogger)
 	}
 }
@@ -136,8 +140,7 @@ func TestNewClient_WithOptions(t *testing.T) {
 	customClient := &http.Client{
 		Timeout: 60 * time.Second,
 	}
-	customLogger := log.New()
-	customLogger.SetLevel(log.InfoLevel)
+	customLogger := newLevelLogger(slog.LevelInfo)
 
 	client := NewClient(false, WithHTTPClient(customClient), WithLogger(customLogger))
 
@@ -145,7 +148,7 @@ func TestNewClient_WithOptions(t *testing.T) {
 		t.Errorf("Client.httpClient = %p, want %p", client.httpClient, customClient)
 	}
 
-	if client.Logger != customLogger {
+	if client.Logger != Logger(customLogger) {
 		t.Errorf("Client.Logger = %p, want %p", client.Logger, customLogger)
 	}
 
@@ -171,12 +174,11 @@ func TestNewExchangeAPI_WithOptions(t *testing.T) {
 }
 
 func TestNewInfoAPI_WithOptions(t *testing.T) {
-	customLogger := log.New()
-	customLogger.SetLevel(log.FatalLevel)
+	customLogger := newLevelLogger(slog.LevelError + 4)
 
 	api := NewInfoAPI(false, WithLogger(customLogger))
 
-	if api.Logger != customLogger {
+	if api.Logger != Logger(customLogger) {
 		t.Errorf("InfoAPI.Logger = %p, want %p", api.Logger, customLogger)
 	}
 
@@ -240,3 +242,41 @@ func TestClientOptions_WithDebugAndOtherOptions(t *testing.T) {
 	}
 }
 
+func TestClientOptions_WithSlogLogger(t *testing.T) {
+	customLogger := slog.New(slog.DiscardHandler)
+
+	hl := NewHyperliquid(&HyperliquidClientConfig{
+		IsMainnet:      false,
+		PrivateKey:     "",
+		AccountAddress: "",
+	}, WithSlogLogger(customLogger))
+
+	if hl.ExchangeAPI.Logger != Logger(customLogger) {
+		t.Errorf("ExchangeAPI.Logger = %p, want %p", hl.ExchangeAPI.Logger, customLogger)
+	}
+
+	if hl.InfoAPI.Logger != Logger(customLogger) {
+		t.Errorf("InfoAPI.Logger = %p, want %p", hl.InfoAPI.Logger, customLogger)
+	}
+
+	// The default logger is the default slog logger of the application
+	if logger, ok := NewClient(false).Logger.(*slog.Logger); !ok || logger != slog.Default() {
+		t.Errorf("default Logger = %T, want slog.Default()", NewClient(false).Logger)
+	}
+}
+
+func TestClientOptions_NilLoggers(t *testing.T) {
+	var nilSlog *slog.Logger
+	options := map[string]ClientOption{
+		"WithLogger":           WithLogger(nil),
+		"WithCustomLogger":     WithCustomLogger(nil),
+		"WithSlogLogger":       WithSlogLogger(nil),
+		"WithLogger nil slog":  WithLogger(nilSlog),
+		"WithCustomLogger nil": WithCustomLogger(Logger(nilSlog)),
+	}
+	for name, option := range options {
+		if logger := NewClient(false, option).Logger; logger != Logger(slog.Default()) {
+			t.Errorf("%s(nil) Logger = %v, want slog.Default()", name, logger)
+		}
+	}
+}
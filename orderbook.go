@@ -0,0 +1,258 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Order book stream constants
+const ORDER_BOOK_STALE_TIMEOUT = 30 * time.Second     // Reconnect if nothing is received for this long
+const ORDER_BOOK_PING_INTERVAL = 20 * time.Second     // Keep alive interval, the server closes idle connections after 60s
+const ORDER_BOOK_MIN_BACKOFF = 500 * time.Millisecond // First reconnect delay
+const ORDER_BOOK_MAX_BACKOFF = 30 * time.Second       // Maximum reconnect delay
+
+var errCrossedBook = errors.New("crossed book")
+
+// OrderBookManager maintains the latest L2 book of a set of coins from the l2Book WebSocket stream.
+//
+// Books are replaced as a whole on every update and are never modified once published,
+// so Snapshot can be called from any goroutine without locking.
+// An update is only accepted if it is newer than the current book and is not crossed.
+// The books are resynced from GetL2Book on every (re)connection and when a crossed update is received.
+//
+// Example:
+//
+//	manager := hyperliquid.NewOrderBookManager(infoAPI, []string{"BTC", "ETH"})
+//	go manager.Run(ctx)
+//	updates, unsubscribe := manager.Subscribe(16)
+//	defer unsubscribe()
+//	for book := range updates {
+//		fmt.Println(book.Coin, book.Mid())
+//	}
+type OrderBookManager struct {
+	info  *InfoAPI
+	url   string
+	coins []string
+	books map[string]*atomic.Pointer[Book] // Built once, only the pointers change
+
+	subsMu    sync.Mutex
+	subs      map[int]chan *Book
+	nextSubId int
+
+	staleTimeout time.Duration
+	pingInterval time.Duration
+}
+
+// NewOrderBookManager returns a manager for the books of the given coins.
+// The WebSocket URL is selected from the network of infoAPI.
+// Call Run to start streaming.
+func NewOrderBookManager(infoAPI *InfoAPI, coins []string) *OrderBookManager {
+	url := TESTNET_WS_URL
+	if infoAPI.IsMainnet() {
+		url = MAINNET_WS_URL
+	}
+	books := make(map[string]*atomic.Pointer[Book], len(coins))
+	for _, coin := range coins {
+		books[coin] = &atomic.Pointer[Book]{}
+	}
+	return &OrderBookManager{
+		info:         infoAPI,
+		url:          url,
+		coins:        coins,
+		books:        books,
+		subs:         make(map[int]chan *Book),
+		staleTimeout: ORDER_BOOK_STALE_TIMEOUT,
+		pingInterval: ORDER_BOOK_PING_INTERVAL,
+	}
+}
+
+// Snapshot returns the latest book of a coin.
+// Returns nil if the coin is not managed or no book has been received yet.
+// The returned book must not be modified.
+func (m *OrderBookManager) Snapshot(coin string) *Book {
+	ptr, ok := m.books[coin]
+	if !ok {
+		return nil
+	}
+	return ptr.Load()
+}
+
+// Subscribe returns a channel receiving every accepted book and a function to stop the subscription.
+// Updates are dropped if the channel buffer is full, Snapshot always returns the latest book.
+func (m *OrderBookManager) Subscribe(buffer int) (<-chan *Book, func()) {
+	ch := make(chan *Book, buffer)
+	m.subsMu.Lock()
+	id := m.nextSubId
+	m.nextSubId++
+	m.subs[id] = ch
+	m.subsMu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			m.subsMu.Lock()
+			delete(m.subs, id)
+			m.subsMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// notify sends the book to every subscriber without blocking.
+func (m *OrderBookManager) notify(book *Book) {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	for _, ch := range m.subs {
+		select {
+		case ch <- book:
+		default:
+		}
+	}
+}
+
+// apply stores the book if it is newer than the current one and consistent.
+// Returns false if the book was ignored.
+// Only the Run goroutine writes books, so the load and store do not race.
+func (m *OrderBookManager) apply(book *Book) (bool, error) {
+	ptr, ok := m.books[book.Coin]
+	if !ok {
+		return false, nil
+	}
+	if current := ptr.Load(); current != nil && book.Time <= current.Time {
+		return false, nil
+	}
+	bid, okBid := book.BestBid()
+	ask, okAsk := book.BestAsk()
+	if okBid && okAsk && bid.Px >= ask.Px {
+		return false, fmt.Errorf("%w: %s bid %v >= ask %v at %d", errCrossedBook, book.Coin, bid.Px, ask.Px, book.Time)
+	}
+	ptr.Store(book)
+	m.notify(book)
+	return true, nil
+}
+
+// resync replaces the book of a coin with a REST snapshot.
+// The snapshot is subject to the same checks as stream updates.
+func (m *OrderBookManager) resync(coin string) error {
+	book, err := m.info.GetL2Book(coin, 0, 0)
+	if err != nil {
+		return err
+	}
+	_, err = m.apply(book)
+	return err
+}
+
+// handleMessage applies an l2Book message and resyncs the coin if the update is inconsistent.
+func (m *OrderBookManager) handleMessage(data []byte) error {
+	var msg struct {
+		Channel string          `json:"channel"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	if msg.Channel != "l2Book" {
+		return nil
+	}
+	var snapshot L2BookSnapshot
+	if err := json.Unmarshal(msg.Data, &snapshot); err != nil {
+		return err
+	}
+	_, err := m.apply(snapshot.Book())
+	if errors.Is(err, errCrossedBook) {
+		m.info.debug("Order book: %s, resyncing", err)
+		return m.resync(snapshot.Coin)
+	}
+	return err
+}
+
+// Run streams the books until ctx is canceled.
+// The connection is reestablished with an exponential backoff when it fails or goes stale.
+// Returns ctx.Err() once canceled.
+func (m *OrderBookManager) Run(ctx context.Context) error {
+	backoff := ORDER_BOOK_MIN_BACKOFF
+	for {
+		start := time.Now()
+		err := m.runConnection(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		m.info.debug("Order book stream error: %s", err)
+		if time.Since(start) > ORDER_BOOK_MAX_BACKOFF {
+			backoff = ORDER_BOOK_MIN_BACKOFF
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, ORDER_BOOK_MAX_BACKOFF)
+	}
+}
+
+// runConnection subscribes to every coin, resyncs the books and reads messages until an error occurs.
+func (m *OrderBookManager) runConnection(ctx context.Context) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, m.url, nil)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	var writeMu sync.Mutex
+	write := func(v any) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(v)
+	}
+	go func() {
+		ticker := time.NewTicker(m.pingInterval)
+		defer ticker.Stop()
+		defer conn.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := write(map[string]string{"method": "ping"}); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for _, coin := range m.coins {
+		subscription := map[string]any{
+			"method":       "subscribe",
+			"subscription": map[string]string{"type": "l2Book", "coin": coin},
+		}
+		if err := write(subscription); err != nil {
+			return err
+		}
+	}
+	// Messages may have been missed while disconnected
+	for _, coin := range m.coins {
+		if err := m.resync(coin); err != nil {
+			m.info.debug("Order book resync of %s failed: %s", coin, err)
+		}
+	}
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(m.staleTimeout)); err != nil {
+			return err
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if err := m.handleMessage(data); err != nil {
+			m.info.debug("Order book message error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package hyperliquid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func l2BookMessage(coin string, time int64, bid string, ask string) string {
+	return fmt.Sprintf(`{"channel": "l2Book", "data": {"coin": "%s", "time": %d, "levels": [[{"px": "%s", "sz": "1", "n": 1}], [{"px": "%s", "sz": "1", "n": 1}]]}}`, coin, time, bid, ask)
+}
+
+// newTestOrderBookManager returns a manager using a fake server for both the info endpoint and the stream.
+// The stream sends the given messages after the subscription and keeps the connection open.
+func newTestOrderBookManager(t *testing.T, snapshot string, messages []string) *OrderBookManager {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/info" {
+			w.Write([]byte(snapshot))
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		for _, message := range messages {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	info := &InfoAPI{baseEndpoint: "/info", Client: *NewClient(false)}
+	info.baseUrl = server.URL
+	manager := NewOrderBookManager(info, []string{"BTC"})
+	manager.url = "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	return manager
+}
+
+func TestOrderBookManager_Apply(t *testing.T) {
+	manager := NewOrderBookManager(&InfoAPI{Client: *NewClient(false)}, []string{"BTC"})
+	updates, unsubscribe := manager.Subscribe(4)
+	defer unsubscribe()
+	book := func(time int64, bid float64, ask float64) *Book {
+		return &Book{
+			Coin: "BTC",
+			Time: time,
+			Bids: []BookLevel{{Px: bid, Sz: 1, N: 1}},
+			Asks: []BookLevel{{Px: ask, Sz: 1, N: 1}},
+		}
+	}
+	if ok, err := manager.apply(book(2, 100, 101)); !ok || err != nil {
+		t.Fatalf("apply() = %v, %v, want accepted", ok, err)
+	}
+	if ok, _ := manager.apply(book(1, 99, 100)); ok {
+		t.Errorf("apply() accepted an older book")
+	}
+	if ok, err := manager.apply(book(3, 102, 101)); ok || err == nil {
+		t.Errorf("apply() = %v, %v, want crossed book error", ok, err)
+	}
+	if ok, _ := manager.apply(&Book{Coin: "ETH", Time: 5}); ok {
+		t.Errorf("apply() accepted an unmanaged coin")
+	}
+	if res := manager.Snapshot("BTC"); res == nil || res.Time != 2 {
+		t.Errorf("Snapshot() = %+v, want book at time 2", res)
+	}
+	if res := manager.Snapshot("ETH"); res != nil {
+		t.Errorf("Snapshot() = %+v, want nil", res)
+	}
+	if len(updates) != 1 {
+		t.Errorf("Subscribe() received %v updates, want %v", len(updates), 1)
+	}
+}
+
+func TestOrderBookManager_Run(t *testing.T) {
+	snapshot := `{"coin": "BTC", "time": 10, "levels": [[{"px": "99", "sz": "1", "n": 1}], [{"px": "101", "sz": "1", "n": 1}]]}`
+	messages := []string{
+		`{"channel": "subscriptionResponse", "data": {}}`,
+		l2BookMessage("BTC", 5, "98", "100"),   // older than the REST snapshot
+		l2BookMessage("BTC", 11, "100", "100"), // crossed, resynced from REST
+		l2BookMessage("BTC", 12, "100", "102"),
+	}
+	manager := newTestOrderBookManager(t, snapshot, messages)
+	updates, unsubscribe := manager.Subscribe(16)
+	defer unsubscribe()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() { errCh <- manager.Run(ctx) }()
+
+	var times []int64
+	for len(times) < 2 {
+		select {
+		case book := <-updates:
+			times = append(times, book.Time)
+		case <-ctx.Done():
+			t.Fatalf("Run() received %v, want updates at times 10 and 12", times)
+		}
+	}
+	if times[0] != 10 || times[1] != 12 {
+		t.Errorf("Run() updates = %v, want [10 12]", times)
+	}
+	book := manager.Snapshot("BTC")
+	if book == nil || book.Mid() != 101 {
+		t.Errorf("Snapshot() = %+v, want mid 101", book)
+	}
+	cancel()
+	if err := <-errCh; err != context.Canceled {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+}
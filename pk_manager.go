@@ -2,25 +2,53 @@ package hyperliquid
 
 import (
 	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
 )
 
+// PKeyManager holds the private key signing the actions.
+// The private key is never exposed as a string, call Wipe to erase it from memory once it is not needed.
+// Wipe waits for the signatures in progress, it is safe to call while other goroutines sign.
 type PKeyManager struct {
-	PrivateKeyStr string
-	privateKey    *ecdsa.PrivateKey
-	publicKey     *ecdsa.PublicKey
+	mu         sync.RWMutex // guards privateKey against Wipe
+	privateKey *ecdsa.PrivateKey
+	publicKey  *ecdsa.PublicKey
 }
 
 func (km *PKeyManager) PublicECDSA() *ecdsa.PublicKey {
 	return km.publicKey
 }
 
+// PrivateECDSA returns the private key, nil after Wipe.
+// The returned key is erased by Wipe, use the signers of the API to sign concurrently with Wipe.
 func (km *PKeyManager) PrivateECDSA() *ecdsa.PrivateKey {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
 	return km.privateKey
 }
 
+// sign signs a 32 bytes hash with the private key, Wipe waits for the signature to complete.
+func (km *PKeyManager) sign(hash []byte) ([]byte, error) {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	if km.privateKey == nil {
+		return nil, APIError{Message: "Private key is not set or was wiped"}
+	}
+	return crypto.Sign(hash, km.privateKey)
+}
+
 func (km *PKeyManager) PublicAddress() common.Address {
 	return crypto.PubkeyToAddress(*km.publicKey)
 }
@@ -29,15 +57,153 @@ func (km *PKeyManager) PublicAddressHex() string {
 	return km.PublicAddress().Hex()
 }
 
-// NewPKeyManager creates a new PKeyManager instance from a private key string
+// Wipe overwrites the private key in memory. The manager cannot sign anymore,
+// its public key and address stay available.
+func (km *PKeyManager) Wipe() {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if km.privateKey == nil {
+		return
+	}
+	clearBigInt(km.privateKey.D)
+	km.privateKey = nil
+}
+
+// clearBigInt overwrites the words of x in memory and sets it to 0.
+func clearBigInt(x *big.Int) {
+	clear(x.Bits())
+	x.SetInt64(0)
+}
+
+// newPKeyManagerFromECDSA creates a PKeyManager from a parsed private key.
+func newPKeyManagerFromECDSA(privKey *ecdsa.PrivateKey) (*PKeyManager, error) {
+	publicKey, ok := privKey.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, APIError{Message: "Invalid private key: no ECDSA public key"}
+	}
+	return &PKeyManager{privateKey: privKey, publicKey: publicKey}, nil
+}
+
+// NewPKeyManager creates a new PKeyManager instance from a hex private key, with or without 0x prefix.
 func NewPKeyManager(privateKey string) (*PKeyManager, error) {
+	privateKey = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"), "0X")
 	privKey, err := crypto.HexToECDSA(privateKey)
 	if err != nil {
 		return nil, err
 	}
-	publicKey, ok := privKey.Public().(*ecdsa.PublicKey)
-	if !ok {
+	return newPKeyManagerFromECDSA(privKey)
+}
+
+// NewPKeyManagerFromKeystore creates a PKeyManager from a go-ethereum v3 keystore JSON encrypted with passphrase.
+func NewPKeyManagerFromKeystore(keyJSON []byte, passphrase string) (*PKeyManager, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting keystore: %w", err)
+	}
+	return newPKeyManagerFromECDSA(key.PrivateKey)
+}
+
+// NewPKeyManagerFromKeystoreFile creates a PKeyManager from a go-ethereum v3 keystore file encrypted with passphrase.
+func NewPKeyManagerFromKeystoreFile(path string, passphrase string) (*PKeyManager, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPKeyManagerFromKeystore(keyJSON, passphrase)
+}
+
+// NewPKeyManagerFromMnemonic creates a PKeyManager from a BIP-39 mnemonic and its optional passphrase.
+// The key is derived with BIP-32 along path, DEFAULT_DERIVATION_PATH (the first account of
+// Ethereum wallets) is used when path is empty.
+func NewPKeyManagerFromMnemonic(mnemonic string, passphrase string, path string) (*PKeyManager, error) {
+	if path == "" {
+		path = DEFAULT_DERIVATION_PATH
+	}
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	defer clear(seed)
+	privKey, err := deriveBIP32Key(seed, derivationPath)
+	if err != nil {
 		return nil, err
 	}
-	return &PKeyManager{privateKey: privKey, publicKey: publicKey, PrivateKeyStr: privateKey}, nil
+	return newPKeyManagerFromECDSA(privKey)
+}
+
+// NewPKeyManagerFromEnv creates a PKeyManager from the hex private key stored in an environment variable.
+func NewPKeyManagerFromEnv(name string) (*PKeyManager, error) {
+	privateKey, ok := os.LookupEnv(name)
+	if !ok || strings.TrimSpace(privateKey) == "" {
+		return nil, APIError{Message: fmt.Sprintf("Environment variable %s is not set", name)}
+	}
+	return NewPKeyManager(privateKey)
+}
+
+// NewPKeyManagerFromFile creates a PKeyManager from a file holding a hex private key.
+func NewPKeyManagerFromFile(path string) (*PKeyManager, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(data)
+	return NewPKeyManager(string(data))
+}
+
+// deriveBIP32Key derives the private key of path from a BIP-32 master seed.
+// The intermediate keys and chain codes are erased from memory.
+func deriveBIP32Key(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	defer clear(sum)
+	key := new(big.Int).SetBytes(sum[:32])
+	defer clearBigInt(key)
+	chainCode := make([]byte, 32)
+	copy(chainCode, sum[32:])
+	defer clear(chainCode)
+	tweak := new(big.Int)
+	defer clearBigInt(tweak)
+
+	curveN := crypto.S256().Params().N
+	if key.Sign() == 0 || key.Cmp(curveN) >= 0 {
+		return nil, APIError{Message: "Invalid BIP-32 master key"}
+	}
+	keyBytes := make([]byte, 32)
+	defer clear(keyBytes)
+	for _, index := range path {
+		key.FillBytes(keyBytes)
+		data := make([]byte, 0, 37)
+		if index >= 0x80000000 {
+			// Hardened child: 0x00 || private key
+			data = append(data, 0)
+			data = append(data, keyBytes...)
+		} else {
+			// Normal child: compressed public key
+			x, y := crypto.S256().ScalarBaseMult(keyBytes)
+			data = append(data, crypto.CompressPubkey(&ecdsa.PublicKey{Curve: crypto.S256(), X: x, Y: y})...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		clear(data)
+		child := mac.Sum(nil)
+		tweak.SetBytes(child[:32])
+		copy(chainCode, child[32:])
+		clear(child)
+		if tweak.Cmp(curveN) >= 0 {
+			return nil, APIError{Message: fmt.Sprintf("Invalid BIP-32 child key at index %d", index)}
+		}
+		key.Add(key, tweak).Mod(key, curveN)
+		if key.Sign() == 0 {
+			return nil, APIError{Message: fmt.Sprintf("Invalid BIP-32 child key at index %d", index)}
+		}
+	}
+	key.FillBytes(keyBytes)
+	return crypto.ToECDSA(keyBytes)
 }
@@ -0,0 +1,194 @@
+package hyperliquid
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestNewPKeyManager(t *testing.T) {
+	km, err := NewPKeyManager(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewPKeyManager() error = %v", err)
+	}
+	prefixed, err := NewPKeyManager("0x" + testPrivateKey + "\n")
+	if err != nil {
+		t.Fatalf("NewPKeyManager() error = %v", err)
+	}
+	if prefixed.PublicAddress() != km.PublicAddress() {
+		t.Errorf("NewPKeyManager() with 0x prefix = %v, want %v", prefixed.PublicAddress(), km.PublicAddress())
+	}
+	if _, err := NewPKeyManager("not a key"); err == nil {
+		t.Errorf("NewPKeyManager() expected an error for an invalid key")
+	}
+}
+
+func TestNewPKeyManagerFromMnemonic(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "default path", path: "", want: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{name: "second account", path: "m/44'/60'/0'/0/1", want: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km, err := NewPKeyManagerFromMnemonic(testMnemonic, "", tt.path)
+			if err != nil {
+				t.Fatalf("NewPKeyManagerFromMnemonic() error = %v", err)
+			}
+			if got := km.PublicAddressHex(); got != tt.want {
+				t.Errorf("NewPKeyManagerFromMnemonic() address = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := NewPKeyManagerFromMnemonic("test test test test test test test test test test test test", "", ""); err == nil {
+		t.Errorf("NewPKeyManagerFromMnemonic() expected an error for an invalid checksum")
+	}
+	if _, err := NewPKeyManagerFromMnemonic(testMnemonic, "", "m/44'/60'/x"); err == nil {
+		t.Errorf("NewPKeyManagerFromMnemonic() expected an error for an invalid path")
+	}
+}
+
+func TestDeriveBIP32Key(t *testing.T) {
+	// Test vectors 1 and 3 of BIP-32, the private keys of the published xprv
+	vector1 := "000102030405060708090a0b0c0d0e0f"
+	vector3 := "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be"
+	tests := []struct {
+		seed string
+		path string
+		want string
+	}{
+		{seed: vector1, path: "m", want: "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{seed: vector1, path: "m/0'", want: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{seed: vector1, path: "m/0'/1", want: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{seed: vector1, path: "m/0'/1/2'", want: "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{seed: vector1, path: "m/0'/1/2'/2", want: "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{seed: vector1, path: "m/0'/1/2'/2/1000000000", want: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+		{seed: vector3, path: "m", want: "00ddb80b067e0d4993197fe10f2657a844a384589847602d56f0c629c81aae32"},
+		{seed: vector3, path: "m/0'", want: "491f7a2eebc7b57028e0d3faa0acda02e75c33b03c48fb288c41e2ea44e1daef"},
+	}
+	for _, tt := range tests {
+		seed, _ := hex.DecodeString(tt.seed)
+		var path accounts.DerivationPath
+		if tt.path != "m" {
+			var err error
+			if path, err = accounts.ParseDerivationPath(tt.path); err != nil {
+				t.Fatalf("ParseDerivationPath(%v) error = %v", tt.path, err)
+			}
+		}
+		key, err := deriveBIP32Key(seed, path)
+		if err != nil {
+			t.Fatalf("deriveBIP32Key(%v) error = %v", tt.path, err)
+		}
+		if got := hex.EncodeToString(crypto.FromECDSA(key)); got != tt.want {
+			t.Errorf("deriveBIP32Key(%v, %v) = %v, want %v", tt.seed[:8], tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNewPKeyManagerFromKeystore(t *testing.T) {
+	account, err := keystore.StoreKey(t.TempDir(), "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatalf("StoreKey() error = %v", err)
+	}
+	km, err := NewPKeyManagerFromKeystoreFile(account.URL.Path, "passphrase")
+	if err != nil {
+		t.Fatalf("NewPKeyManagerFromKeystoreFile() error = %v", err)
+	}
+	if km.PublicAddress() != account.Address {
+		t.Errorf("NewPKeyManagerFromKeystoreFile() address = %v, want %v", km.PublicAddress(), account.Address)
+	}
+	if _, err := NewPKeyManagerFromKeystoreFile(account.URL.Path, "wrong"); err == nil {
+		t.Errorf("NewPKeyManagerFromKeystoreFile() expected an error for a wrong passphrase")
+	}
+}
+
+func TestNewPKeyManagerFromEnvAndFile(t *testing.T) {
+	want, err := NewPKeyManager(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewPKeyManager() error = %v", err)
+	}
+	t.Setenv("HL_TEST_PRIVATE_KEY", "0x"+testPrivateKey)
+	km, err := NewPKeyManagerFromEnv("HL_TEST_PRIVATE_KEY")
+	if err != nil {
+		t.Fatalf("NewPKeyManagerFromEnv() error = %v", err)
+	}
+	if km.PublicAddress() != want.PublicAddress() {
+		t.Errorf("NewPKeyManagerFromEnv() address = %v, want %v", km.PublicAddress(), want.PublicAddress())
+	}
+	if _, err := NewPKeyManagerFromEnv("HL_TEST_MISSING_PRIVATE_KEY"); err == nil {
+		t.Errorf("NewPKeyManagerFromEnv() expected an error for a missing variable")
+	}
+
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, []byte(testPrivateKey+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	km, err = NewPKeyManagerFromFile(path)
+	if err != nil {
+		t.Fatalf("NewPKeyManagerFromFile() error = %v", err)
+	}
+	if km.PublicAddress() != want.PublicAddress() {
+		t.Errorf("NewPKeyManagerFromFile() address = %v, want %v", km.PublicAddress(), want.PublicAddress())
+	}
+}
+
+func TestPKeyManager_Wipe(t *testing.T) {
+	km, err := NewPKeyManager(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewPKeyManager() error = %v", err)
+	}
+	address := km.PublicAddress()
+	privateKey := km.PrivateECDSA()
+	km.Wipe()
+	if km.PrivateECDSA() != nil || privateKey.D.Sign() != 0 {
+		t.Errorf("Wipe() did not erase the private key")
+	}
+	if km.PublicAddress() != address {
+		t.Errorf("Wipe() changed the address to %v", km.PublicAddress())
+	}
+	signer := NewSigner(km)
+	if _, _, _, err := signer.Sign(&SignRequest{PrimaryType: "Agent", DomainName: "Exchange"}); err == nil {
+		t.Errorf("Sign() expected an error after Wipe()")
+	}
+}
+
+func TestPKeyManager_WipeWhileSigning(t *testing.T) {
+	km, err := NewPKeyManager(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewPKeyManager() error = %v", err)
+	}
+	signer := NewSigner(km)
+	request, err := buildL1SignRequest(dummyAction{Type: "dummy", Num: 100000000000}, 0, "", nil, true)
+	if err != nil {
+		t.Fatalf("buildL1SignRequest() error = %v", err)
+	}
+	// Signatures either complete with the key or fail once it is wiped, run with -race
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				if _, _, _, err := signer.Sign(request); err != nil {
+					return
+				}
+			}
+		}()
+	}
+	km.Wipe()
+	wg.Wait()
+	if _, _, _, err := signer.Sign(request); err == nil {
+		t.Errorf("Sign() expected an error after Wipe()")
+	}
+}
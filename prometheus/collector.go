@@ -0,0 +1,118 @@
+// Package prometheus exposes the metrics of the Hyperliquid clients as a Prometheus collector.
+//
+//	collector := prometheus.NewCollector("")
+//	registry.MustRegister(collector)
+//	client := hyperliquid.NewHyperliquid(config, hyperliquid.WithMetrics(collector))
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	hyperliquid "github.com/chainswatch/go-hyperliquid"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// DEFAULT_NAMESPACE prefixes the metric names when NewCollector is given an empty namespace
+const DEFAULT_NAMESPACE = "hyperliquid"
+
+// Collector implements hyperliquid.Metrics and prometheus.Collector.
+// One collector can be shared by every client of the process.
+type Collector struct {
+	requests       *prom.CounterVec
+	latency        *prom.HistogramVec
+	exchangeErrors *prom.CounterVec
+	orders         *prom.CounterVec
+	rateLimitUsed  *prom.GaugeVec
+	rateLimitCap   *prom.GaugeVec
+	cumVolume      *prom.GaugeVec
+}
+
+var _ hyperliquid.Metrics = (*Collector)(nil)
+var _ prom.Collector = (*Collector)(nil)
+
+// NewCollector returns a collector with metric names prefixed by namespace, DEFAULT_NAMESPACE if empty.
+func NewCollector(namespace string) *Collector {
+	if namespace == "" {
+		namespace = DEFAULT_NAMESPACE
+	}
+	return &Collector{
+		requests: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Requests sent to the API by endpoint, info or action type and HTTP status (0 without response).",
+		}, []string{"endpoint", "type", "status"}),
+		latency: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the requests sent to the API by endpoint and info or action type.",
+			Buckets:   prom.DefBuckets,
+		}, []string{"endpoint", "type"}),
+		exchangeErrors: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "exchange_errors_total",
+			Help:      "Errors returned by the exchange by action type and kind.",
+		}, []string{"action", "kind"}),
+		orders: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_total",
+			Help:      "Orders by outcome: placed (resting or filled), filled on placement or rejected.",
+		}, []string{"outcome"}),
+		rateLimitUsed: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_requests_used",
+			Help:      "Requests used by the user, as last seen with userRateLimit.",
+		}, []string{"user"}),
+		rateLimitCap: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_requests_cap",
+			Help:      "Requests allowed to the user, as last seen with userRateLimit.",
+		}, []string{"user"}),
+		cumVolume: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_cum_volume_usd",
+			Help:      "Cumulative traded volume of the user, as last seen with userRateLimit.",
+		}, []string{"user"}),
+	}
+}
+
+func (c *Collector) collectors() []prom.Collector {
+	return []prom.Collector{c.requests, c.latency, c.exchangeErrors, c.orders, c.rateLimitUsed, c.rateLimitCap, c.cumVolume}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// ObserveRequest implements hyperliquid.Metrics.
+func (c *Collector) ObserveRequest(endpoint string, requestType string, status int, latency time.Duration) {
+	c.requests.WithLabelValues(endpoint, requestType, strconv.Itoa(status)).Inc()
+	c.latency.WithLabelValues(endpoint, requestType).Observe(latency.Seconds())
+}
+
+// IncExchangeError implements hyperliquid.Metrics.
+func (c *Collector) IncExchangeError(action string, kind string) {
+	c.exchangeErrors.WithLabelValues(action, kind).Inc()
+}
+
+// AddOrders implements hyperliquid.Metrics.
+func (c *Collector) AddOrders(outcome string, count int) {
+	c.orders.WithLabelValues(outcome).Add(float64(count))
+}
+
+// SetRateLimit implements hyperliquid.Metrics.
+func (c *Collector) SetRateLimit(user string, limits hyperliquid.RatesLimits) {
+	c.rateLimitUsed.WithLabelValues(user).Set(float64(limits.NRequestsUsed))
+	c.rateLimitCap.WithLabelValues(user).Set(float64(limits.NRequestsCap))
+	c.cumVolume.WithLabelValues(user).Set(limits.CumVlm)
+}
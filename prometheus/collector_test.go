@@ -0,0 +1,45 @@
+package prometheus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	hyperliquid "github.com/chainswatch/go-hyperliquid"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector("")
+	registry := prom.NewRegistry()
+	registry.MustRegister(collector)
+
+	collector.ObserveRequest("exchange", "order", 200, 120*time.Millisecond)
+	collector.ObserveRequest("exchange", "order", 200, 80*time.Millisecond)
+	collector.IncExchangeError("order", hyperliquid.EXCHANGE_ERROR_INSUFFICIENT_MARGIN)
+	collector.AddOrders(hyperliquid.ORDER_PLACED, 2)
+	collector.SetRateLimit("0xabc", hyperliquid.RatesLimits{CumVlm: 1000, NRequestsUsed: 20, NRequestsCap: 11000})
+
+	if got := testutil.ToFloat64(collector.requests.WithLabelValues("exchange", "order", "200")); got != 2 {
+		t.Errorf("requests_total = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(collector.orders.WithLabelValues(hyperliquid.ORDER_PLACED)); got != 2 {
+		t.Errorf("orders_total = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(collector.rateLimitUsed.WithLabelValues("0xabc")); got != 20 {
+		t.Errorf("rate_limit_requests_used = %v, want 20", got)
+	}
+
+	expected := `
+# HELP hyperliquid_exchange_errors_total Errors returned by the exchange by action type and kind.
+# TYPE hyperliquid_exchange_errors_total counter
+hyperliquid_exchange_errors_total{action="order",kind="insufficient_margin"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "hyperliquid_exchange_errors_total"); err != nil {
+		t.Error(err)
+	}
+	if count := testutil.CollectAndCount(collector); count != 7 {
+		t.Errorf("CollectAndCount() = %d, want 7", count)
+	}
+}
@@ -0,0 +1,110 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+// Keys whose values are secrets, compared case-insensitively
+var secretKeys = []string{"signature", "signatures", "privatekey", "private_key", "secret", "mnemonic", "passphrase", "password"}
+
+// Keys whose 32 bytes hex values are private keys or signature components, compared case-insensitively.
+// Other 32 bytes hex values (hash, multiSigActionHash, tx hashes) are kept.
+var secretHexKeys = []string{"key", "pk", "privkey", "seed", "r", "s"}
+
+var (
+	addressPattern   = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	secretHexPattern = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+)
+
+// redactBody returns a loggable version of a JSON body.
+// Signatures and private keys are replaced by [REDACTED], addresses are masked when redactAddresses is set.
+// Bodies that are not JSON are returned as is. The result is truncated to limit bytes (no limit if limit <= 0).
+func redactBody(data []byte, redactAddresses bool, limit int) string {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // keep nonces and sizes as written
+	if err := decoder.Decode(&value); err == nil {
+		if redacted, err := json.Marshal(redactValue(value, "", redactAddresses)); err == nil {
+			data = redacted
+		}
+	}
+	return truncateBody(data, limit)
+}
+
+// redactValue redacts the secrets of a decoded JSON value, key is the key holding the value (the one of the array for its items).
+func redactValue(value any, key string, redactAddresses bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for itemKey, item := range v {
+			if isSecretKey(itemKey) {
+				v[itemKey] = redactedValue
+				continue
+			}
+			v[itemKey] = redactValue(item, itemKey, redactAddresses)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, key, redactAddresses)
+		}
+		return v
+	case string:
+		if secretHexPattern.MatchString(v) && slices.Contains(secretHexKeys, strings.ToLower(key)) {
+			return redactedValue
+		}
+		if redactAddresses && addressPattern.MatchString(v) {
+			return maskAddress(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretKeys {
+		if key == secret {
+			return true
+		}
+	}
+	return false
+}
+
+// maskAddress keeps the first and last 4 hex digits of an address: 0x1234…abcd
+func maskAddress(address string) string {
+	return address[:6] + "…" + address[len(address)-4:]
+}
+
+// truncateBody returns the first limit bytes of data (all of it if limit <= 0).
+func truncateBody(data []byte, limit int) string {
+	if limit <= 0 || len(data) <= limit {
+		return string(data)
+	}
+	return fmt.Sprintf("%s…(%d bytes truncated)", data[:limit], len(data)-limit)
+}
+
+// requestSummary returns the action type and nonce of an /info or /exchange payload, when present.
+func requestSummary(payload []byte) (actionType string, nonce uint64) {
+	var summary struct {
+		Type   string `json:"type"`
+		Nonce  uint64 `json:"nonce"`
+		Action struct {
+			Type string `json:"type"`
+		} `json:"action"`
+	}
+	// Payloads with an action that is not an object still give their nonce
+	_ = json.Unmarshal(payload, &summary)
+	actionType = summary.Type
+	if summary.Action.Type != "" {
+		actionType = summary.Action.Type
+	}
+	return actionType, summary.Nonce
+}
@@ -0,0 +1,91 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testSignatureR = "0x2d6a7a6bb3b4e1fcb8a4b7b7c1f1a6a91c5c2d6e2d8e8f4c7b1a3e1f0a2b3c4d"
+
+func TestRedactBody(t *testing.T) {
+	address := "0x1234567890abcdef1234567890abcdef12345678"
+	hash := "0xf7421e925dc701a9ce5f7688b7706fd7f4e5eebcf6aeb52516277f8e31c4f2a3"
+	payload := []byte(`{"action":{"type":"withdraw3","destination":"` + address + `","amount":"1"},` +
+		`"nonce":1700000000000,"signature":{"r":"` + testSignatureR + `","s":"0x01","v":27},` +
+		`"privateKey":"abc","key":"` + testPrivateKey + `","hash":"` + hash + `","txHashes":["` + hash + `"]}`)
+
+	got := redactBody(payload, false, 0)
+	for _, secret := range []string{testSignatureR, testPrivateKey, `"abc"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("redactBody() = %s, contains %s", got, secret)
+		}
+	}
+	// Hashes are not secrets
+	for _, kept := range []string{address, "1700000000000", `"type":"withdraw3"`, `"hash":"` + hash + `"`, `"txHashes":["` + hash + `"]`} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("redactBody() = %s, want %s", got, kept)
+		}
+	}
+
+	got = redactBody(payload, true, 0)
+	if strings.Contains(got, address) || !strings.Contains(got, "0x1234…5678") {
+		t.Errorf("redactBody() with redacted addresses = %s", got)
+	}
+
+	got = redactBody([]byte("not json "+strings.Repeat("x", 100)), false, 10)
+	if got != "not json x…(99 bytes truncated)" {
+		t.Errorf("redactBody() truncated = %q", got)
+	}
+}
+
+func TestRequestSummary(t *testing.T) {
+	tests := []struct {
+		payload   string
+		wantType  string
+		wantNonce uint64
+	}{
+		{payload: `{"type":"l2Book","coin":"ETH"}`, wantType: "l2Book"},
+		{payload: `{"action":{"type":"order"},"nonce":42}`, wantType: "order", wantNonce: 42},
+		{payload: `{"action":["a"],"nonce":42}`, wantNonce: 42},
+	}
+	for _, tt := range tests {
+		actionType, nonce := requestSummary([]byte(tt.payload))
+		if actionType != tt.wantType || nonce != tt.wantNonce {
+			t.Errorf("requestSummary(%s) = %q, %d, want %q, %d", tt.payload, actionType, nonce, tt.wantType, tt.wantNonce)
+		}
+	}
+}
+
+func TestClient_RequestDebugLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok","response":{"type":"default"}}`))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(true, WithSlogLogger(logger), WithDebug(true))
+	client.baseUrl = server.URL
+
+	request := ExchangeRequest{
+		Action:    map[string]any{"type": "order"},
+		Nonce:     1700000000000,
+		Signature: RsvSignature{R: testSignatureR, S: testSignatureR, V: 27},
+	}
+	if _, err := client.Request("/exchange", request); err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	got := out.String()
+	if strings.Contains(got, testSignatureR) {
+		t.Errorf("debug log contains the signature: %s", got)
+	}
+	for _, field := range []string{`"endpoint":"exchange"`, `"action":"order"`, `"nonce":1700000000000`, `"status":200`, `"latency"`} {
+		if !strings.Contains(got, field) {
+			t.Errorf("debug log = %s, want field %s", got, field)
+		}
+	}
+}
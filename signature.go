@@ -1,9 +1,11 @@
 package hyperliquid
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
-	"log"
+	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -55,6 +57,7 @@ func (request *SignRequest) GetDomain() apitypes.TypedDataDomain {
 
 type Signer struct {
 	manager *PKeyManager
+	logger  Logger // logs signing errors, nil for none
 }
 
 func NewSigner(manager *PKeyManager) Signer {
@@ -63,21 +66,38 @@ func NewSigner(manager *PKeyManager) Signer {
 	}
 }
 
+// NewSignerWithLogger returns a Signer logging its errors to logger.
+func NewSignerWithLogger(manager *PKeyManager, logger Logger) Signer {
+	return Signer{
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// logError logs a signing error if the signer has a logger.
+func (signer *Signer) logError(msg string, err error) {
+	if signer.logger != nil {
+		signer.logger.Error(msg, "error", err)
+	}
+}
+
 func (signer *Signer) Sign(request *SignRequest) (byte, [32]byte, [32]byte, error) {
 	return signer.signInternal(SignRequestToEIP712TypedData(request))
 }
 
 // signInternal signs the typed data and returns the signature in VRS format
 func (signer *Signer) signInternal(message apitypes.TypedData) (byte, [32]byte, [32]byte, error) {
-	pkey := signer.manager.PrivateECDSA()
+	if signer.manager == nil || signer.manager.PrivateECDSA() == nil {
+		return 0, [32]byte{}, [32]byte{}, APIError{Message: "Private key is not set or was wiped"}
+	}
 	bytes, _, err := apitypes.TypedDataAndHash(message)
 	if err != nil {
-		log.Printf("Error hashing typed data: %s", err)
+		signer.logError("Error hashing typed data", err)
 		return 0, [32]byte{}, [32]byte{}, err
 	}
-	signature, err := crypto.Sign(bytes, pkey)
+	signature, err := signer.manager.sign(bytes)
 	if err != nil {
-		log.Printf("Error signing typed data: %s", err)
+		signer.logError("Error signing typed data", err)
 		return 0, [32]byte{}, [32]byte{}, err
 	}
 	return SignatureToVRS(signature)
@@ -102,9 +122,22 @@ func SignatureToVRS(sig []byte) (byte, [32]byte, [32]byte, error) {
 	return v, r, s, nil
 }
 
+// packAction serializes an action with msgpack like the Python SDK does.
+// Integers are packed with the smallest type that holds them, whatever their Go type.
+func packAction(action any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.UseCompactInts(true)
+	if err := enc.Encode(action); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Create a hash of an action (json object)
-func buildActionHash(action any, vaultAd string, nonce uint64) (common.Hash, error) {
-	data, err := msgpack.Marshal(action)
+// expiresAfter is appended to the hashed data only when it is set.
+func buildActionHash(action any, vaultAd string, nonce uint64, expiresAfter *uint64) (common.Hash, error) {
+	data, err := packAction(action)
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("error while marshaling action: %s", err)
 	}
@@ -118,6 +151,12 @@ func buildActionHash(action any, vaultAd string, nonce uint64) (common.Hash, err
 		data = ArrayAppend(data, []byte("\x01"))
 		data = ArrayAppend(data, HexToBytes(vaultAd))
 	}
+	if expiresAfter != nil {
+		expiresAfterBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(expiresAfterBytes, *expiresAfter)
+		data = ArrayAppend(data, []byte("\x00"))
+		data = ArrayAppend(data, expiresAfterBytes)
+	}
 	result := crypto.Keccak256Hash(data)
 	return result, nil
 }
@@ -138,3 +177,128 @@ func buildMessage(hash []byte, isMainnet bool) apitypes.TypedDataMessage {
 		"connectionId": hash,
 	}
 }
+
+// buildL1SignRequest builds the EIP-712 request signed for an L1 action.
+// The action is hashed with msgpack, the typed data only carries the hash.
+func buildL1SignRequest(action any, nonce uint64, vaultAddress string, expiresAfter *uint64, isMainnet bool) (*SignRequest, error) {
+	hash, err := buildActionHash(action, vaultAddress, nonce, expiresAfter)
+	if err != nil {
+		return nil, err
+	}
+	return &SignRequest{
+		DomainName:  "Exchange",
+		PrimaryType: "Agent",
+		DType: []apitypes.Type{
+			{
+				Name: "source",
+				Type: "string",
+			},
+			{
+				Name: "connectionId",
+				Type: "bytes32",
+			},
+		},
+		DTypeMsg:  buildMessage(hash.Bytes(), isMainnet),
+		IsMainNet: isMainnet,
+	}, nil
+}
+
+// buildUserSignRequest builds the EIP-712 request signed for a user signed action.
+// The fields of the action are signed directly, except type and signatureChainId.
+func buildUserSignRequest(action any, payloadTypes []apitypes.Type, primaryType string, isMainnet bool) (*SignRequest, error) {
+	message, err := StructToMap(action)
+	if err != nil {
+		return nil, err
+	}
+	// Remove unnecessary fields for signing
+	delete(message, "type")
+	delete(message, "signatureChainId")
+
+	return &SignRequest{
+		DomainName:  "HyperliquidSignTransaction",
+		PrimaryType: primaryType,
+		DType:       payloadTypes,
+		DTypeMsg:    message,
+		IsMainNet:   isMainnet,
+	}, nil
+}
+
+// SignRequestDigest returns the EIP-712 digest signed for the request.
+func SignRequestDigest(request *SignRequest) (common.Hash, error) {
+	digest, _, err := apitypes.TypedDataAndHash(SignRequestToEIP712TypedData(request))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(digest), nil
+}
+
+// RecoverSigner returns the address that produced the signature of the request.
+// V can be 27/28 as sent to the exchange or 0/1.
+func RecoverSigner(request *SignRequest, sig RsvSignature) (common.Address, error) {
+	digest, err := SignRequestDigest(request)
+	if err != nil {
+		return common.Address{}, err
+	}
+	r, err := parseSignatureComponent(sig.R)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature r: %w", err)
+	}
+	s, err := parseSignatureComponent(sig.S)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature s: %w", err)
+	}
+	v := sig.V
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return common.Address{}, fmt.Errorf("invalid signature v=%d", sig.V)
+	}
+	signature := make([]byte, 65)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:64])
+	signature[64] = v
+	pub, err := crypto.SigToPub(digest.Bytes(), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// parseSignatureComponent parses the hex r or s of a signature. The Python SDK drops the leading zeros,
+// so odd lengths and values shorter than 32 bytes are accepted.
+func parseSignatureComponent(value string) (*big.Int, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	n, ok := new(big.Int).SetString(digits, 16)
+	if !ok || digits == "" || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid hex %q", value)
+	}
+	if n.BitLen() > 256 {
+		return nil, fmt.Errorf("%q is longer than 32 bytes", value)
+	}
+	return n, nil
+}
+
+// RecoverL1ActionSigner returns the address that signed an L1 action.
+// The action must be the value that was signed (the *Action types of this package),
+// since its msgpack encoding is hashed. vaultAddress is empty and expiresAfter nil when unused.
+//
+// A recovered address that is neither the account nor one of its approved agents
+// is the cause of "User or API Wallet does not exist" errors.
+func RecoverL1ActionSigner(action any, nonce uint64, vaultAddress string, expiresAfter *uint64, isMainnet bool, sig RsvSignature) (common.Address, error) {
+	request, err := buildL1SignRequest(action, nonce, vaultAddress, expiresAfter, isMainnet)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return RecoverSigner(request, sig)
+}
+
+// RecoverUserSignedActionSigner returns the address that signed a user signed action (withdraw, transfers, approveAgent...).
+// payloadTypes and primaryType are the ones used to sign the action, see the Sign*Action methods of ExchangeAPI.
+func RecoverUserSignedActionSigner(action any, payloadTypes []apitypes.Type, primaryType string, isMainnet bool, sig RsvSignature) (common.Address, error) {
+	request, err := buildUserSignRequest(action, payloadTypes, primaryType, isMainnet)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return RecoverSigner(request, sig)
+}
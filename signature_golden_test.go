@@ -0,0 +1,445 @@
+package hyperliquid
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Golden vectors of action hashing and signing with the key testPrivateKey.
+//
+// Vectors with source "python" are the published test vectors of the official Python SDK
+// (tests/signing_test.py), they prove that the msgpack encoding of the action matches the
+// one of the exchange. Vectors with source "reference" and "regression" were computed with a
+// standalone implementation of the Python SDK signing helpers (multi_sig included), which
+// reproduces the published vectors above, from the actions as the Python SDK Exchange builds them.
+// The "reference" vectors cover the multi-sig flow. The "regression" vectors cover the other actions
+// the Python SDK tests have no vector for: they were not checked against the exchange and only
+// protect the encoding of these actions from regressions.
+// User signed vectors are on testnet, the Python SDK always signs them with the 0x66eee chain id.
+// r and s are written with their leading zeros, which the Python SDK drops.
+//
+// A failing vector means the hash of the action changed: the exchange will reject the signature
+// with "User or API Wallet does not exist" or recover an unknown address.
+
+// dummyAction is the action used by the Python SDK vectors, num is float_to_int_for_hashing(1000).
+type dummyAction struct {
+	Type string `msgpack:"type" json:"type"`
+	Num  int64  `msgpack:"num" json:"num"`
+}
+
+type goldenL1Vector struct {
+	name         string
+	source       string
+	action       any
+	nonce        uint64
+	vaultAddress string
+	expiresAfter *uint64
+	connectionId string // mainnet action hash
+	mainnet      RsvSignature
+	testnet      RsvSignature
+}
+
+type goldenUserSignedVector struct {
+	name        string
+	source      string
+	action      any
+	types       []apitypes.Type // types and primaryType of actions without a type field
+	primaryType string
+	isMainnet   bool
+	sig         RsvSignature
+}
+
+func goldenOrderAction(orderType OrderType, cloid string) PlaceOrderAction {
+	wire := OrderRequestToWire(OrderRequest{
+		Coin:       "ETH",
+		IsBuy:      true,
+		Sz:         100,
+		LimitPx:    100,
+		OrderType:  orderType,
+		ReduceOnly: false,
+		Cloid:      cloid,
+	}, map[string]AssetInfo{"ETH": {AssetId: 1}}, false)
+	return OrderWiresToOrderAction([]OrderWire{wire}, GroupingNa)
+}
+
+// Multi-sig account, outer signer (address of testPrivateKey) and nonce of the multi-sig vectors
+const (
+	goldenMultiSigUser    = "0x1719884eb866cb12b2287399b15f7db5e7d775ea"
+	goldenMultiSigOuter   = "0x14791697260e4c9a71f18484c9f997b308e59325"
+	goldenMultiSigNonce   = uint64(1700000000000)
+	goldenMultiSigHashHex = "0xf7421e925dc701a9ce5f7688b7706fd7f4e5eebcf6aeb52516277f8e31c4f2a3"
+)
+
+// goldenMultiSigAction is the multiSig action sent by the outer signer for the order of the
+// "Multi-sig order" vector, with the testnet signature of the authorized user.
+func goldenMultiSigAction() MultiSigAction {
+	return MultiSigAction{
+		Type:             "multiSig",
+		SignatureChainID: "0x66eee",
+		Signatures: []RsvSignature{
+			{R: "0xd6210105b47204c38b0e09c155566830193231d83160e8c75fe4d9053f0d3668", S: "0x1e933018f93b4594aaca4601cf8fe6524ff1bc125f0174b35ed3c4929dda6c5c", V: 27},
+		},
+		Payload: MultiSigPayload{
+			MultiSigUser: goldenMultiSigUser,
+			OuterSigner:  goldenMultiSigOuter,
+			Action:       goldenOrderAction(OrderType{Limit: &LimitOrderType{Tif: TifGtc}}, ""),
+		},
+	}
+}
+
+func goldenL1Vectors() []goldenL1Vector {
+	limitGtc := OrderType{Limit: &LimitOrderType{Tif: TifGtc}}
+	expiresAfter := uint64(1700000060000)
+	return []goldenL1Vector{
+		{
+			name:         "Dummy action",
+			source:       "python",
+			action:       dummyAction{Type: "dummy", Num: 100000000000},
+			connectionId: "0xf528daee6a0bd11407b483cfcd9a48c56884180b70ee86f124053e5fc1bf4d57",
+			mainnet:      RsvSignature{R: "0x053749d5b30552aeb2fca34b530185976545bb22d0b3ce6f62e31be961a59298", S: "0x755c40ba9bf05223521753995abb2f73ab3229be8ec921f350cb447e384d8ed8", V: 27},
+			testnet:      RsvSignature{R: "0x542af61ef1f429707e3c76c5293c80d01f74ef853e34b76efffcb57e574f9510", S: "0x17b8b32f086e8cdede991f1e2c529f5dd5297cbe8128500e00cbaf766204a613", V: 28},
+		},
+		{
+			name:         "Dummy action with vault",
+			source:       "python",
+			action:       dummyAction{Type: "dummy", Num: 100000000000},
+			vaultAddress: "0x1719884eb866cb12b2287399b15f7db5e7d775ea",
+			connectionId: "0xde9e09a7a3da45cc694096d4bfdcd89bc1b892d05497c5ecc1f56c335945184c",
+			mainnet:      RsvSignature{R: "0x003c548db75e479f8012acf3000ca3a6b05606bc2ec0c29c50c515066a326239", S: "0x4d402be7396ce74fbba3795769cda45aec00dc3125a984f2a9f23177b190da2c", V: 28},
+			testnet:      RsvSignature{R: "0xe281d2fb5c6e25ca01601f878e4d69c965bb598b88fac58e475dd1f5e56c362b", S: "0x7ddad27e9a238d045c035bc606349d075d5c5cd00a6cd1da23ab5c39d4ef0f60", V: 27},
+		},
+		{
+			name:         "Order",
+			source:       "python",
+			action:       goldenOrderAction(limitGtc, ""),
+			connectionId: "0x884f2c32bb6dbdd65f6033e32fb28c0cb6f5b345db0f6471fd3366d85c9252c1",
+			mainnet:      RsvSignature{R: "0xd65369825a9df5d80099e513cce430311d7d26ddf477f5b3a33d2806b100d78e", S: "0x2b54116ff64054968aa237c20ca9ff68000f977c93289157748a3162b6ea940e", V: 28},
+			testnet:      RsvSignature{R: "0x82b2ba28e76b3d761093aaded1b1cdad4960b3af30212b343fb2e6cdfa4e3d54", S: "0x6b53878fc99d26047f4d7e8c90eb98955a109f44209163f52d8dc4278cbbd9f5", V: 27},
+		},
+		{
+			name:         "Order with cloid",
+			source:       "python",
+			action:       goldenOrderAction(limitGtc, "0x00000000000000000000000000000001"),
+			connectionId: "0x0ba500cedd8f4ba6ded620a0b1cd04f124d9ba745e2e2893fcc763bcc1444af5",
+			mainnet:      RsvSignature{R: "0x041ae18e8239a56cacbc5dad94d45d0b747e5da11ad564077fcac71277a946e3", S: "0x3c61f667e747404fe7eea8f90ab0e76cc12ce60270438b2058324681a00116da", V: 27},
+			testnet:      RsvSignature{R: "0xeba0664bed2676fc4e5a743bf89e5c7501aa6d870bdb9446e122c9466c5cd16d", S: "0x7f3e74825c9114bc59086f1eebea2928c190fdfbfde144827cb02b85bbe90988", V: 28},
+		},
+		{
+			name:         "Trigger order",
+			source:       "python",
+			action:       goldenOrderAction(OrderType{Trigger: &TriggerOrderType{IsMarket: true, TriggerPx: "103", TpSl: TriggerSl}}, ""),
+			connectionId: "0x430a86fb9876e901920d931f5bb20c9d011f6389bd179f39a73c09e6219adcad",
+			mainnet:      RsvSignature{R: "0x98343f2b5ae8e26bb2587daad3863bc70d8792b09af1841b6fdd530a2065a3f9", S: "0x6b5bb6bb0633b710aa22b721dd9dee6d083646a5f8e581a20b545be6c1feb405", V: 27},
+			testnet:      RsvSignature{R: "0x971c554d917c44e0e1b6cc45d8f9404f32172a9d3b3566262347d0302896a2e4", S: "0x206257b104788f80450f8e786c329daa589aa0b32ba96948201ae556d5637eac", V: 28},
+		},
+		{
+			name:         "Create sub-account",
+			source:       "python",
+			action:       CreateSubAccountAction{Type: "createSubAccount", Name: "example"},
+			connectionId: "0x9a7b5272baf65d28b0589bd50863a42ac35897553a6beb274e625b6faf7d6bb1",
+			mainnet:      RsvSignature{R: "0x51096fe3239421d16b671e192f574ae24ae14329099b6db28e479b86cdd6caa7", S: "0x0b71f7d293af92d3772572afb8b102d167a7cef7473388286bc01f52a5c5b423", V: 27},
+			testnet:      RsvSignature{R: "0xa699e3ed5c2b89628c746d3298b5dc1cca604694c2c855da8bb8250ec8014a5b", S: "0x53f1b8153a301c72ecc655b1c315d64e1dcea3ee58921fd7507e35818fcc1584", V: 28},
+		},
+		{
+			name:         "Sub-account transfer",
+			source:       "python",
+			action:       SubAccountTransferAction{Type: "subAccountTransfer", SubAccountUser: "0x1d9470d4b963f552e6f671a81619d395877bf409", IsDeposit: true, Usd: 10},
+			connectionId: "0xd12f71eba9e3e792812bfdf01a6a92f4d4016bb0541e850b41f770891c0cc447",
+			mainnet:      RsvSignature{R: "0x43592d7c6c7d816ece2e206f174be61249d651944932b13343f4d13f306ae602", S: "0x71a926cb5c9a7c01c3359ec4c4c34c16ff8107d610994d4de0e6430e5cc0f4c9", V: 28},
+			testnet:      RsvSignature{R: "0xe26574013395ad55ee2f4e0575310f003c5bb3351b5425482e2969fa51543927", S: "0x0efb08999196366871f919fd0e138b3a7f30ee33e678df7cfaf203e25f0a4278", V: 28},
+		},
+		{
+			name:         "Cancel",
+			source:       "regression",
+			action:       CancelOidOrderAction{Type: "cancel", Cancels: []CancelOidWire{{Asset: 1, Oid: 123456789}}},
+			nonce:        1700000000000,
+			connectionId: "0x9375cdf74004a5fdeb2f4e21e14d7ba2c1db0329fe61515982945f157d3f0069",
+			mainnet:      RsvSignature{R: "0x0921c4ea675ba8c76d384a63acf2adb50aab08a9b44269c8aed1cd03917fcd40", S: "0x417d3845b5f3d95d60fea4ab67207a3bf281de88ae89b8ccf72af029163a8c6a", V: 27},
+			testnet:      RsvSignature{R: "0x11c9d87e57e3a06e768de9fc54fd43f926c101a52109175132c991c69f0f26d8", S: "0x456e3f4eabf96cc67a6af0507cb2230dd6cfe90e6c7b57504760b95468741a51", V: 28},
+		},
+		{
+			name:         "Cancel by cloid",
+			source:       "regression",
+			action:       CancelCloidOrderAction{Type: "cancelByCloid", Cancels: []CancelCloidWire{{Asset: 1, Cloid: "0x00000000000000000000000000000001"}}},
+			nonce:        1700000000000,
+			connectionId: "0xff888b53d8ec27155197e50698343967aa922eba74ef605d7f2b1b1dd57c10de",
+			mainnet:      RsvSignature{R: "0xd8ad694a3002d9d3e462be876e1e0975d0cb990265ca8c3dffbd144e10c84db5", S: "0x6e760fa62e1f9f1e69d38878fab2ff660fa170b38073dbcde110279f786aa77e", V: 28},
+			testnet:      RsvSignature{R: "0xc276d64cd29914b727beb7a5cf318c0e8b3b9727ed5bfb630f9759899dc7ef21", S: "0x588aa55be2e086e5cf276d17e9a8cd150bfd1342ce0dcfa899bf49ae8bbe12ef", V: 28},
+		},
+		{
+			name:   "Batch modify",
+			source: "regression",
+			action: ModifyOrderAction{Type: "batchModify", Modifies: []ModifyOrderWire{
+				{OrderId: 123456789, Order: goldenOrderAction(limitGtc, "").Orders[0]},
+			}},
+			nonce:        1700000000000,
+			connectionId: "0x489529db2967ee2b94806afdbc76f7011dad4f85617160ee253f7fc66d948cd4",
+			mainnet:      RsvSignature{R: "0xbb331d1d02f11f1adb6cb9921cd1abbcc321d598cd6c8f71c9b6e1e087f80d54", S: "0x06421ca65ccdaba0d446c8d09cd38cb01c902b8b896cac9a68773fd3a21c32e6", V: 28},
+			testnet:      RsvSignature{R: "0x52a6dba59f2f12bb62ef21b890841c47556db434440610ab1f6c7c125bf73efd", S: "0x44812bf465a6a3ceef991d02897a37eed4b3fff490b778f5b90bba92e3be0bfc", V: 28},
+		},
+		{
+			name:         "Update leverage",
+			source:       "regression",
+			action:       UpdateLeverageAction{Type: "updateLeverage", Asset: 1, IsCross: true, Leverage: 10},
+			nonce:        1700000000000,
+			connectionId: "0x489fa82a9161798ae35b4c0ee08cd77cec550edd58b7cbc9cb6e87393b297b63",
+			mainnet:      RsvSignature{R: "0x625793ab577398503fc2573c3ba237f868517b5dad8205a76fbae8dafc8f362f", S: "0x0a0029b107ddd59bec09bd8571e8fc4ac9efe1d9acef99ff01b1a5e391877258", V: 28},
+			testnet:      RsvSignature{R: "0x1aa09db4f2e99885f33834542e1f2eb6c0dae76f3d4fc0702bb35efe4930db72", S: "0x34605cb3c25107371fb5e5b3b76a3fa53353a4c3519a99cb9b2bd940e643ff9a", V: 28},
+		},
+		{
+			name:         "Vault transfer",
+			source:       "regression",
+			action:       VaultTransferAction{Type: "vaultTransfer", VaultAddress: "0x1719884eb866cb12b2287399b15f7db5e7d775ea", IsDeposit: true, Usd: 5000000},
+			nonce:        1700000000000,
+			connectionId: "0xb974966aa6554835291cb85bdd65990bfc4f8c08f27e15877634693bfa381b8a",
+			mainnet:      RsvSignature{R: "0xc9edc37d4c557fd445dee76391b1f387a9210571e13da79fee395e8434c58b22", S: "0x44a381185add06b1f71b06ce839860edc1b7b83382882c55ca89daf3c2f754cb", V: 28},
+			testnet:      RsvSignature{R: "0x09559cf8fa41eca906b034d0bbf914e44d521dd1cffa4146f874aa70471458a5", S: "0x54e146d703b5cc50ba87c9c339b2644fad40b8c41cf74060dd4ba0f4f5ba81fb", V: 27},
+		},
+		{
+			name:         "Sub-account spot transfer",
+			source:       "regression",
+			action:       SubAccountSpotTransferAction{Type: "subAccountSpotTransfer", SubAccountUser: "0x1d9470d4b963f552e6f671a81619d395877bf409", IsDeposit: false, Token: "PURR:0xc1fb593aeffbeb02f85e0308e9956a90", Amount: "12.5"},
+			nonce:        1700000000000,
+			connectionId: "0x3fa83169257cd5aeee4df934af0f00d113af4c93429a383e7481f1762e575971",
+			mainnet:      RsvSignature{R: "0x82c48339e6ad4f969fffbc8e638595aeda5ae94bdc25d698de104b9add39cabd", S: "0x04b9ba0b32b44d29933938c1761e1c1e1f277faa9873b3fb7f74c14da453d1d5", V: 28},
+			testnet:      RsvSignature{R: "0x56ffbb82aa7145624ba271f30fa86998a6043d80b8d9521b0c11c10fb1d9ece2", S: "0x590cdf4d706abd33566cef909032f396604d95931ccf9be54c165637e79d857b", V: 28},
+		},
+		{
+			name:         "Set referrer",
+			source:       "regression",
+			action:       SetReferrerAction{Type: "setReferrer", Code: "TESTCODE"},
+			nonce:        1700000000000,
+			connectionId: "0x9f1e2d8cffec546ab3ba681a291e01e5527c59db3c42787ee726ec0f80d98e8a",
+			mainnet:      RsvSignature{R: "0x5c680524da9451f6dbe993efb24aeaf9c3c7e89b8099cac1440ccef9d6fa7158", S: "0x19120bb05dc0473c9c00d04e79f17e6b8e7d83682d06f0ba8cc1d92bd93d326f", V: 27},
+			testnet:      RsvSignature{R: "0xb26829800e2b7f2a86df5cfbb90cd31a0b46b4b933a8e935e0c80a48abc73c63", S: "0x394a345e7fcc8fc0d32d37a498708937c4df4477b517734d1cb1c7faea1a09bb", V: 28},
+		},
+		{
+			name:         "Register referrer",
+			source:       "regression",
+			action:       RegisterReferrerAction{Type: "registerReferrer", Code: "TESTCODE"},
+			nonce:        1700000000000,
+			connectionId: "0x98166a096e7da31d46c4338b9ea2021deb3c561fa4802cd33b0831dd0cdd7e1e",
+			mainnet:      RsvSignature{R: "0xdfc2428fa30dbd7f5fbeb9f02bd2e9ba111890212192db65e492046c5dfe3d00", S: "0x7b5524c4b1d3a10098311e6f0301752fcead8ff9dc1e525014a9f67f998f52f6", V: 28},
+			testnet:      RsvSignature{R: "0x2d758944ff12d2c904f1f45d2e351cbff641bbbfadb4bdfe12bdcc739f9d3d24", S: "0x1ae5090c4cc3574c1e5ac45093380d6f456c683e96b3a76f20685ab9f8f3a4f9", V: 27},
+		},
+		{
+			name:         "Order with expiresAfter",
+			source:       "regression",
+			action:       goldenOrderAction(limitGtc, ""),
+			nonce:        1700000000000,
+			expiresAfter: &expiresAfter,
+			connectionId: "0x0792f0d788465669c3ff7809bb0e3797dff08f3c78090387a9bdb9e57623530f",
+			mainnet:      RsvSignature{R: "0xa07e4477eb151bb6024ff6134f29c69204b32b6224c13a9fbadd8ad7df697b8c", S: "0x15652fe061dc2f613dde90926462a114e57e069b73ba264d98adb813734adea7", V: 27},
+			testnet:      RsvSignature{R: "0x2ca554750316ce9bad3bc4aa1159de382dc9ef899006e26e6ac227ea7bd96426", S: "0x1ff5c702a4732a1bdb303a11f58f5de005cad195adf03b2477c5998be80b8d5d", V: 27},
+		},
+		{
+			name:         "Vault order with expiresAfter",
+			source:       "regression",
+			action:       goldenOrderAction(limitGtc, ""),
+			nonce:        1700000000000,
+			vaultAddress: "0x1719884eb866cb12b2287399b15f7db5e7d775ea",
+			expiresAfter: &expiresAfter,
+			connectionId: "0x6c3194841adb1383248be40c6a17610cf32eea1b78be74c3c16183cd9800eb2b",
+			mainnet:      RsvSignature{R: "0x6237226640420b78100e887b28019bebe0a754ad45a47a512bcc2b376aca9fc2", S: "0x0927690ef47855f776fae20c766b69475480e649d0d2f55d9e9553931ca8cbbf", V: 28},
+			testnet:      RsvSignature{R: "0x07f9d8e175585e2fb697da5f45469ceb2aab8f21be390962ab002ab08aa0a873", S: "0x676e93a6bd1a4aae7f4c5bacd997e973bba1f2cb6483c12ba253e2dd51fc0115", V: 27},
+		},
+		{
+			// sign_multi_sig_l1_action_payload: the order wrapped with the multi-sig user and the outer signer
+			name:         "Multi-sig order",
+			source:       "reference",
+			action:       []any{goldenMultiSigUser, goldenMultiSigOuter, goldenOrderAction(limitGtc, "")},
+			nonce:        goldenMultiSigNonce,
+			connectionId: "0xf06997a95d64419f8cbfebcbfd58e074fb300fa661b0a185a98f9c1ca5bfb526",
+			mainnet:      RsvSignature{R: "0x7d19078ee3744751047f34a5ab86c995fab2ad52d1c4cb7e1e276e066386b25b", S: "0x588df4c8f1171205df8d592ee609c783fda36c506e8b714ffc4ef44c8c5d87b2", V: 28},
+			testnet:      RsvSignature{R: "0xd6210105b47204c38b0e09c155566830193231d83160e8c75fe4d9053f0d3668", S: "0x1e933018f93b4594aaca4601cf8fe6524ff1bc125f0174b35ed3c4929dda6c5c", V: 27},
+		},
+	}
+}
+
+// goldenMultiSigSigners returns the signers of the "Convert to multi-sig user" vector,
+// serialized by ConvertToMultiSigUser.
+func goldenMultiSigSigners() string {
+	signers, _ := multiSigSignersJSON([]string{"0x5e9ee1089755c3435139848e47e6635505d5a13a", "0x1d9470d4b963f552e6f671a81619d395877bf409"}, 2)
+	return signers
+}
+
+func goldenUserSignedVectors() []goldenUserSignedVector {
+	return []goldenUserSignedVector{
+		{
+			name:   "Withdraw",
+			source: "python",
+			action: WithdrawAction{Type: "withdraw3", Destination: "0x5e9ee1089755c3435139848e47e6635505d5a13a", Amount: "1", Time: 1687816341423, HyperliquidChain: "Testnet", SignatureChainID: "0x66eee"},
+			sig:    RsvSignature{R: "0x8363524c799e90ce9bc41022f7c39b4e9bdba786e5f9c72b20e43e1462c37cf9", S: "0x58b1411a775938b83e29182e8ef74975f9054c8e97ebf5ec2dc8d51bfc893881", V: 28},
+		},
+		{
+			name:   "Approve agent",
+			source: "regression",
+			action: ApproveAgentAction{Type: "approveAgent", HyperliquidChain: "Testnet", SignatureChainID: "0x66eee", AgentAddress: "0x1d9470d4b963f552e6f671a81619d395877bf409", AgentName: "bot", Nonce: 1700000000000},
+			sig:    RsvSignature{R: "0x51a46b29aabc40d67e34afcdcdfb6a09a3e1e4bc46f4427162051dbb6f283c0a", S: "0x4ed0ed1b28411a5ad87a2ac429c94702dc78927b9f502e4775e0b08fafd207d1", V: 28},
+		},
+		{
+			name:   "Staking deposit",
+			source: "regression",
+			action: CDepositAction{Type: "cDeposit", HyperliquidChain: "Testnet", SignatureChainID: "0x66eee", Wei: 150000000, Nonce: 1700000000000},
+			sig:    RsvSignature{R: "0x08f878eec91ff7574f825d1b0a816bccb9fd4e0083aa89eb0472f6e2d4779649", S: "0x38b7e5bd3e9b219838873434b064a059d916e4dfbba2dd41166e3d5d7ee925cc", V: 28},
+		},
+		{
+			name:   "Staking withdraw",
+			source: "regression",
+			action: CWithdrawAction{Type: "cWithdraw", HyperliquidChain: "Testnet", SignatureChainID: "0x66eee", Wei: 150000000, Nonce: 1700000000000},
+			sig:    RsvSignature{R: "0xfe0a937fdafeaae70687e16c57f8eb87fc7351b19602c02f08ede85383a1168f", S: "0x4c96a9d7aa338fc69d1e0b42ece8e30d8db628f9386e9325dd2e0126c9ab4fce", V: 27},
+		},
+		{
+			name:   "Token delegate",
+			source: "regression",
+			action: TokenDelegateAction{Type: "tokenDelegate", HyperliquidChain: "Testnet", SignatureChainID: "0x66eee", Validator: "0x5ac99df645f3414876c816caa18b2d234024b487", Wei: 150000000, IsUndelegate: false, Nonce: 1700000000000},
+			sig:    RsvSignature{R: "0x39b4c2518a71a86926f0ef4bbef8a2567f3e030d8ea956c05eef3c0a1ed489ab", S: "0x2d0ddaeb6aa6a78099a64e289666456f42d3bb0ae499568207aab60317fc1fac", V: 27},
+		},
+		{
+			// Exchange.convert_to_multi_sig_user, signers are serialized by json.dumps, see goldenMultiSigSigners
+			name:   "Convert to multi-sig user",
+			source: "reference",
+			action: ConvertToMultiSigUserAction{Type: "convertToMultiSigUser", SignatureChainID: "0x66eee", HyperliquidChain: "Testnet",
+				Signers: goldenMultiSigSigners(), Nonce: 1700000000000},
+			sig: RsvSignature{R: "0x7a03e76a03709d5296d2ace9c297bf77cbbd028abccbb41b067dc104fee118b7", S: "0x16b96d9ac6c88c07494b5d7c9c4cc529c4b12486bff2138644428f2d9dc8158b", V: 27},
+		},
+		{
+			// sign_multi_sig_action: envelope of goldenMultiSigAction signed by the outer signer
+			name:        "Multi-sig envelope",
+			source:      "reference",
+			action:      MultiSigEnvelope{HyperliquidChain: "Testnet", MultiSigActionHash: goldenMultiSigHashHex, Nonce: goldenMultiSigNonce},
+			types:       multiSigEnvelopeSignTypes,
+			primaryType: "HyperliquidTransaction:SendMultiSig",
+			sig:         RsvSignature{R: "0xd907f32c51efd36ba23125d6294d9320420e45f4cba22ea4ffb523d86038119b", S: "0x5b9de1db5a3ce29b90b5f1eede4d91db2022cda81d1f16ac4b4aea62314d52e1", V: 27},
+		},
+	}
+}
+
+// userSignedActionType returns the type field of a user signed action.
+func userSignedActionType(action any) string {
+	message, _ := StructToMap(action)
+	actionType, _ := message["type"].(string)
+	return actionType
+}
+
+func TestSignature_GoldenL1Actions(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	for _, vector := range goldenL1Vectors() {
+		t.Run(vector.source+"/"+vector.name, func(t *testing.T) {
+			hash, err := buildActionHash(vector.action, vector.vaultAddress, vector.nonce, vector.expiresAfter)
+			if err != nil {
+				t.Fatalf("buildActionHash() error = %v", err)
+			}
+			if hash.Hex() != vector.connectionId {
+				t.Errorf("buildActionHash() = %v, want %v", hash.Hex(), vector.connectionId)
+			}
+			for _, isMainnet := range []bool{true, false} {
+				expected := vector.testnet
+				if isMainnet {
+					expected = vector.mainnet
+				}
+				request, err := buildL1SignRequest(vector.action, vector.nonce, vector.vaultAddress, vector.expiresAfter, isMainnet)
+				if err != nil {
+					t.Fatalf("buildL1SignRequest() error = %v", err)
+				}
+				v, r, s, err := api.Sign(request)
+				if err != nil {
+					t.Fatalf("Sign() error = %v", err)
+				}
+				if sig := ToTypedSig(r, s, v); sig != expected {
+					t.Errorf("Sign(mainnet=%v) = %+v, want %+v", isMainnet, sig, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestSignature_GoldenUserSignedActions(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	for _, vector := range goldenUserSignedVectors() {
+		t.Run(vector.source+"/"+vector.name, func(t *testing.T) {
+			types, primaryType := vector.types, vector.primaryType
+			if types == nil {
+				var err error
+				types, primaryType, err = UserSignedActionTypes(userSignedActionType(vector.action))
+				if err != nil {
+					t.Fatalf("UserSignedActionTypes() error = %v", err)
+				}
+			}
+			request, err := buildUserSignRequest(vector.action, types, primaryType, vector.isMainnet)
+			if err != nil {
+				t.Fatalf("buildUserSignRequest() error = %v", err)
+			}
+			v, r, s, err := api.Sign(request)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			if sig := ToTypedSig(r, s, v); sig != vector.sig {
+				t.Errorf("Sign() = %+v, want %+v", sig, vector.sig)
+			}
+		})
+	}
+}
+
+func TestSignature_GoldenMultiSigSigners(t *testing.T) {
+	// json.dumps({"authorizedUsers": sorted(authorized_users), "threshold": threshold}) of the Python SDK
+	expected := `{"authorizedUsers": ["0x1d9470d4b963f552e6f671a81619d395877bf409", "0x5e9ee1089755c3435139848e47e6635505d5a13a"], "threshold": 2}`
+	if signers := goldenMultiSigSigners(); signers != expected {
+		t.Errorf("multiSigSignersJSON() = %v, want %v", signers, expected)
+	}
+}
+
+func TestSignature_GoldenMultiSig(t *testing.T) {
+	// The multiSigActionHash is the hash of the action without its type
+	hash, err := buildActionHash(goldenMultiSigAction(), "", goldenMultiSigNonce, nil)
+	if err != nil {
+		t.Fatalf("buildActionHash() error = %v", err)
+	}
+	if hash.Hex() != goldenMultiSigHashHex {
+		t.Errorf("buildActionHash() = %v, want %v", hash.Hex(), goldenMultiSigHashHex)
+	}
+
+	// The helpers of the API produce the signatures of the vectors
+	api := NewOfflineExchangeAPI(false)
+	if err := api.SetPrivateKey(testPrivateKey); err != nil {
+		t.Fatalf("SetPrivateKey() error = %v", err)
+	}
+	action := goldenMultiSigAction()
+	sig, err := api.SignMultiSigL1Action(action.Payload.Action, goldenMultiSigUser, goldenMultiSigOuter, goldenMultiSigNonce)
+	if err != nil {
+		t.Fatalf("SignMultiSigL1Action() error = %v", err)
+	}
+	if sig != action.Signatures[0] {
+		t.Errorf("SignMultiSigL1Action() = %+v, want %+v", sig, action.Signatures[0])
+	}
+	v, r, s, err := api.SignMultiSigEnvelope(action, goldenMultiSigNonce)
+	if err != nil {
+		t.Fatalf("SignMultiSigEnvelope() error = %v", err)
+	}
+	for _, vector := range goldenUserSignedVectors() {
+		if vector.name == "Multi-sig envelope" && ToTypedSig(r, s, v) != vector.sig {
+			t.Errorf("SignMultiSigEnvelope() = %+v, want %+v", ToTypedSig(r, s, v), vector.sig)
+		}
+	}
+}
+
+func TestSignature_GoldenProductionConnectionId(t *testing.T) {
+	// Order sent to the exchange in production, published by the Python SDK
+	wire := OrderRequestToWire(OrderRequest{
+		Coin:      "ETH",
+		IsBuy:     true,
+		Sz:        0.0147,
+		LimitPx:   1670.1,
+		OrderType: OrderType{Limit: &LimitOrderType{Tif: TifIoc}},
+	}, map[string]AssetInfo{"ETH": {AssetId: 4}}, false)
+	action := OrderWiresToOrderAction([]OrderWire{wire}, GroupingNa)
+	hash, err := buildActionHash(action, "", 1677777606040, nil)
+	if err != nil {
+		t.Fatalf("buildActionHash() error = %v", err)
+	}
+	expected := "0x0fcbeda5ae3c4950a548021552a4fea2226858c4453571bf3f24ba017eac2908"
+	if hash.Hex() != expected {
+		t.Errorf("buildActionHash() = %v, want %v", hash.Hex(), expected)
+	}
+}
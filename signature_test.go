@@ -0,0 +1,98 @@
+package hyperliquid
+
+import (
+	"testing"
+)
+
+func TestSignature_RecoverL1ActionSigner(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	action := UpdateLeverageAction{
+		Type:     "updateLeverage",
+		Asset:    0,
+		IsCross:  false,
+		Leverage: 3,
+	}
+	nonce := uint64(1700000000000)
+	vaultAddress := "0x1719884eb866cb12b2287399b15f7db5e7d775ea"
+	v, r, s, err := api.signL1ActionWithOptions(action, nonce, vaultAddress, nil)
+	if err != nil {
+		t.Fatalf("signL1ActionWithOptions() error = %v", err)
+	}
+	sig := ToTypedSig(r, s, v)
+	expected := api.KeyManager().PublicAddress()
+
+	testCases := []struct {
+		name         string
+		nonce        uint64
+		vaultAddress string
+		isMainnet    bool
+		match        bool
+	}{
+		{name: "Same action", nonce: nonce, vaultAddress: vaultAddress, isMainnet: true, match: true},
+		{name: "Other nonce", nonce: nonce + 1, vaultAddress: vaultAddress, isMainnet: true, match: false},
+		{name: "Missing vault", nonce: nonce, vaultAddress: "", isMainnet: true, match: false},
+		{name: "Other network", nonce: nonce, vaultAddress: vaultAddress, isMainnet: false, match: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := RecoverL1ActionSigner(action, tc.nonce, tc.vaultAddress, nil, tc.isMainnet, sig)
+			if err != nil {
+				t.Fatalf("RecoverL1ActionSigner() error = %v", err)
+			}
+			if (signer == expected) != tc.match {
+				t.Errorf("RecoverL1ActionSigner() = %v, want match %v with %v", signer, tc.match, expected)
+			}
+		})
+	}
+
+	// V can also be given as 0/1
+	sig.V -= 27
+	signer, err := RecoverL1ActionSigner(action, nonce, vaultAddress, nil, true, sig)
+	if err != nil || signer != expected {
+		t.Errorf("RecoverL1ActionSigner() = %v, %v, want %v", signer, err, expected)
+	}
+}
+
+func TestSignature_RecoverSignerStrippedLeadingZeros(t *testing.T) {
+	api := GetOfflineExchangeAPI(t)
+	action := UpdateLeverageAction{Type: "updateLeverage", Asset: 0, IsCross: false, Leverage: 3}
+	// r of this signature is 0x0ed20c…, the Python SDK sends it without its leading zero (63 nibbles)
+	sig := RsvSignature{
+		R: "0xed20c1ec7e00fcfddffd4f9f09a107c853735adc20392746f8bebef12b1699b",
+		S: "0x1d584428ecac118f0544ccb6cb56575532b5cc47537d61c64ff6473636b5e5c9",
+		V: 27,
+	}
+	signer, err := RecoverL1ActionSigner(action, 1700000000014, "", nil, true, sig)
+	if err != nil {
+		t.Fatalf("RecoverL1ActionSigner() error = %v", err)
+	}
+	if expected := api.KeyManager().PublicAddress(); signer != expected {
+		t.Errorf("RecoverL1ActionSigner() = %v, want %v", signer, expected)
+	}
+}
+
+func TestSignature_RecoverSignerInvalidSignature(t *testing.T) {
+	request, err := buildL1SignRequest(UpdateLeverageAction{Type: "updateLeverage"}, 1, "", nil, true)
+	if err != nil {
+		t.Fatalf("buildL1SignRequest() error = %v", err)
+	}
+	testCases := []struct {
+		name string
+		sig  RsvSignature
+	}{
+		{name: "Invalid hex", sig: RsvSignature{R: "0xzz", S: "0x01", V: 27}},
+		{name: "Empty", sig: RsvSignature{R: "0x", S: "0x01", V: 27}},
+		{name: "Invalid v", sig: RsvSignature{R: "0x01", S: "0x01", V: 30}},
+		{name: "Too long", sig: RsvSignature{R: "0x" + testPrivateKey + "00", S: "0x01", V: 27}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := RecoverSigner(request, tc.sig); err == nil {
+				t.Errorf("RecoverSigner() error = nil, want error")
+			}
+		})
+	}
+	if _, _, err := UserSignedActionTypes("unknown"); err == nil {
+		t.Errorf("UserSignedActionTypes() error = nil, want unknown action type")
+	}
+}
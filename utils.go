@@ -2,6 +2,7 @@ package hyperliquid
 
 import (
 	"crypto/rand"
+	"math"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -51,21 +52,44 @@ func GetRandomCloid() string {
 }
 
 // Calculate the slippage of a trade
+// The price is rounded to 5 significant figures, up for buys and down for sells,
+// so the limit price never ends up inside the price it was computed from.
 func CalculateSlippage(isBuy bool, px float64, slippage float64) float64 {
 	if isBuy {
 		px = px * (1 + slippage)
 	} else {
 		px = px * (1 - slippage)
 	}
-	// Format the float with a precision of 6 significant figures
-	pxStr := strconv.FormatFloat(px, 'g', 5, 64)
-	// Convert the formatted string to a float
-	pxFloat, err := strconv.ParseFloat(pxStr, 64)
+	return roundPriceSigFigs(px, PRICE_SIG_FIGS, isBuy)
+}
+
+// roundPriceSigFigs rounds px to sigFigs significant figures, towards +inf if up, towards 0 otherwise.
+func roundPriceSigFigs(px float64, sigFigs int, up bool) float64 {
+	if px <= 0 || math.IsInf(px, 0) || math.IsNaN(px) {
+		return px
+	}
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(px, 'g', sigFigs, 64), 64)
 	if err != nil {
 		return px
 	}
-	// Round the float to 6 decimal places
-	return pxFloat
+	// Rounding to nearest went the wrong way, move one unit of the last significant figure.
+	// The tolerance ignores the float noise of px
+	const epsilon = 1e-12
+	unit := math.Pow(10, math.Floor(math.Log10(px))-float64(sigFigs-1))
+	switch {
+	case up && rounded < px*(1-epsilon):
+		rounded += unit
+	case !up && rounded > px*(1+epsilon):
+		rounded -= unit
+	default:
+		return rounded
+	}
+	// Clean the float noise of the addition
+	cleaned, err := strconv.ParseFloat(strconv.FormatFloat(rounded, 'g', sigFigs+1, 64), 64)
+	if err != nil {
+		return rounded
+	}
+	return cleaned
 }
 
 func IsBuy(szi float64) bool {
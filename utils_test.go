@@ -0,0 +1,25 @@
+package hyperliquid
+
+import "testing"
+
+func TestCalculateSlippage_RoundsAwayFromBook(t *testing.T) {
+	tests := []struct {
+		isBuy    bool
+		px       float64
+		slippage float64
+		want     float64
+	}{
+		{isBuy: true, px: 1234.51, slippage: 0.00001, want: 1234.6},  // nearest is 1234.5
+		{isBuy: false, px: 1234.59, slippage: 0.00001, want: 1234.5}, // nearest is 1234.6
+		{isBuy: true, px: 1234.5, slippage: 0, want: 1234.5},
+		{isBuy: false, px: 9999.96, slippage: 0, want: 9999.9},
+		{isBuy: true, px: 99999.5, slippage: 0, want: 100000},
+		{isBuy: true, px: 0.000123456, slippage: 0, want: 0.00012346},
+		{isBuy: true, px: 100, slippage: 0.05, want: 105},
+	}
+	for _, tt := range tests {
+		if got := CalculateSlippage(tt.isBuy, tt.px, tt.slippage); got != tt.want {
+			t.Errorf("CalculateSlippage(%v, %v, %v) = %v, want %v", tt.isBuy, tt.px, tt.slippage, got, tt.want)
+		}
+	}
+}